@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveEntry is a single file in a tar archive that we're streaming to or
+// from a container
+type ArchiveEntry struct {
+	// Path is relative to the parent of the copied path, meaning it starts with
+	// the copied path's base name
+	Path string
+	Size int64
+	Mode os.FileMode
+	// LinkTarget is only set for symlinks
+	LinkTarget string
+}
+
+// IsDir tells us whether the entry is a directory
+func (e ArchiveEntry) IsDir() bool {
+	return e.Mode.IsDir()
+}
+
+// progressReader calls onProgress with the total number of bytes read so far
+// every time it's read from
+type progressReader struct {
+	io.Reader
+	total      int64
+	onProgress func(int64)
+}
+
+func newProgressReader(r io.Reader, onProgress func(int64)) io.Reader {
+	if onProgress == nil {
+		return r
+	}
+
+	return &progressReader{Reader: r, onProgress: onProgress}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.total += int64(n)
+		r.onProgress(r.total)
+	}
+	return n, err
+}
+
+// readArchiveEntries lists the entries in a tar stream without extracting it
+func readArchiveEntries(r io.Reader) ([]ArchiveEntry, error) {
+	tr := tar.NewReader(r)
+	entries := []ArchiveEntry{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, ArchiveEntry{
+			Path:       strings.TrimSuffix(path.Clean(hdr.Name), "/"),
+			Size:       hdr.Size,
+			Mode:       hdr.FileInfo().Mode(),
+			LinkTarget: hdr.Linkname,
+		})
+	}
+}
+
+// readArchiveFile returns up to limit bytes of the first file in a tar stream,
+// along with whether there was more to read
+func readArchiveFile(r io.Reader, limit int64) ([]byte, bool, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, false, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(tr, limit))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return content, hdr.Size > limit, nil
+}
+
+// listHostPath lists the entries that archiving the given host path would
+// produce, in the same form as readArchiveEntries
+func listHostPath(srcPath string) ([]ArchiveEntry, error) {
+	base := filepath.Base(srcPath)
+	entries := []ArchiveEntry{}
+	err := filepath.Walk(srcPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcPath, p)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, _ = os.Readlink(p)
+		}
+
+		entries = append(entries, ArchiveEntry{
+			Path:       path.Join(base, filepath.ToSlash(rel)),
+			Size:       info.Size(),
+			Mode:       info.Mode(),
+			LinkTarget: link,
+		})
+		return nil
+	})
+
+	return entries, err
+}
+
+// renameArchivePath swaps the first component of an archive entry's path
+// (i.e. the base name of the copied path) from oldBase to newBase
+func renameArchivePath(name, oldBase, newBase string) string {
+	name = strings.TrimSuffix(path.Clean(name), "/")
+	if name == oldBase || strings.HasPrefix(name, oldBase+"/") {
+		return newBase + strings.TrimPrefix(name, oldBase)
+	}
+	return name
+}
+
+// rebaseArchive copies a tar stream from r to w, swapping the first component
+// of each entry's path from oldBase to newBase as renameArchivePath does
+func rebaseArchive(r io.Reader, w io.Writer, oldBase, newBase string) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return tw.Close()
+		}
+		if err != nil {
+			return err
+		}
+
+		hdr.Name = renameArchivePath(hdr.Name, oldBase, newBase)
+		if hdr.Typeflag == tar.TypeDir {
+			hdr.Name += "/"
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = renameArchivePath(hdr.Linkname, oldBase, newBase)
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// createArchive writes a tar archive of srcPath to w, with srcPath itself
+// renamed to nameInArchive. Ownership is dropped so that, like with `docker cp`,
+// files end up belonging to root in the container. If exclude is not nil, it
+// tells us which paths (relative to srcPath) to leave out of the archive, and
+// we don't descend into excluded directories.
+func createArchive(w io.Writer, srcPath, nameInArchive string, exclude func(rel string, info os.FileInfo) bool) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(srcPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcPath, p)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." && exclude != nil && exclude(filepath.ToSlash(rel), info) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(nameInArchive, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uid, hdr.Gid = 0, 0
+		hdr.Uname, hdr.Gname = "", ""
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// extractArchive extracts a tar stream into dstDir, renaming the archive's root
+// entry from oldBase to newBase. Entries which would end up outside of dstDir
+// are rejected.
+func extractArchive(r io.Reader, dstDir, oldBase, newBase string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := resolveArchiveTarget(dstDir, renameArchivePath(hdr.Name, oldBase, newBase))
+		if err != nil {
+			return err
+		}
+
+		if err := extractArchiveEntry(tr, hdr, dstDir, target, oldBase, newBase); err != nil {
+			return err
+		}
+	}
+}
+
+func extractArchiveEntry(tr *tar.Reader, hdr *tar.Header, dstDir, target, oldBase, newBase string) error {
+	mode := hdr.FileInfo().Mode().Perm()
+
+	if hdr.Typeflag == tar.TypeDir {
+		return os.MkdirAll(target, mode|0o700)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	// a symlink extracted earlier could point the parent directory somewhere
+	// outside of dstDir, so we check where it really is before writing to it
+	if err := checkWithinDir(dstDir, filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeReg:
+		// an earlier entry of the same name could be a symlink to anywhere on
+		// the host, which opening the file would follow
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(file, tr)
+		return err
+	case tar.TypeSymlink:
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeLink:
+		linkTarget, err := resolveArchiveTarget(dstDir, renameArchivePath(hdr.Linkname, oldBase, newBase))
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		return os.Link(linkTarget, target)
+	default:
+		// devices, fifos and the like can't be meaningfully copied to the host
+		return nil
+	}
+}
+
+// resolveArchiveTarget returns the host path for an archive entry, making sure
+// it can't escape dstDir with e.g. '../'
+func resolveArchiveTarget(dstDir, name string) (string, error) {
+	target := filepath.Join(dstDir, filepath.FromSlash(name))
+	rel, err := filepath.Rel(dstDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path in archive: %s", name)
+	}
+	return target, nil
+}
+
+func checkWithinDir(dir, p string) error {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	realPath, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(realDir, realPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("refusing to write outside of %s: %s", dir, p)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestArchiveRoundTrip is a function.
+func TestArchiveRoundTrip(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(srcDir, "nested"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("hello"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(srcDir, "nested", "b.txt"), []byte("world!"), 0o600))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, createArchive(buf, srcDir, "renamed", nil))
+
+	entries, err := readArchiveEntries(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+
+	paths := []string{}
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	assert.EqualValues(t, []string{"renamed", "renamed/a.txt", "renamed/nested", "renamed/nested/b.txt"}, paths)
+
+	hostEntries, err := listHostPath(srcDir)
+	assert.NoError(t, err)
+	assert.Len(t, hostEntries, len(entries))
+
+	dstDir := t.TempDir()
+	assert.NoError(t, extractArchive(bytes.NewReader(buf.Bytes()), dstDir, "renamed", "copy"))
+
+	content, err := os.ReadFile(filepath.Join(dstDir, "copy", "nested", "b.txt"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "world!", string(content))
+}
+
+// TestExtractArchiveRejectsEscapingPaths is a function.
+func TestExtractArchiveRejectsEscapingPaths(t *testing.T) {
+	type scenario struct {
+		name    string
+		headers []*tar.Header
+	}
+
+	scenarios := []scenario{
+		{
+			"parent directory",
+			[]*tar.Header{
+				{Name: "../evil.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+		},
+		{
+			"through symlink",
+			[]*tar.Header{
+				{Name: "src/link", Typeflag: tar.TypeSymlink, Linkname: "/", Mode: 0o777},
+				{Name: "src/link/evil.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tw := tar.NewWriter(buf)
+			for _, hdr := range s.headers {
+				assert.NoError(t, tw.WriteHeader(hdr))
+			}
+			assert.NoError(t, tw.Close())
+
+			assert.Error(t, extractArchive(buf, t.TempDir(), "src", "src"))
+		})
+	}
+}
+
+// TestExtractArchiveReplacesSymlinks is a function.
+func TestExtractArchiveReplacesSymlinks(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "victim.txt")
+	assert.NoError(t, os.WriteFile(outside, []byte("untouched"), 0o644))
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "src/file", Typeflag: tar.TypeSymlink, Linkname: outside, Mode: 0o777}))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "src/file", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+
+	dstDir := t.TempDir()
+	assert.NoError(t, extractArchive(buf, dstDir, "src", "src"))
+
+	content, err := os.ReadFile(outside)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "untouched", string(content))
+
+	info, err := os.Lstat(filepath.Join(dstDir, "src", "file"))
+	assert.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+}
+
+// TestRenameArchivePath is a function.
+func TestRenameArchivePath(t *testing.T) {
+	assert.EqualValues(t, "new", renameArchivePath("old/", "old", "new"))
+	assert.EqualValues(t, "new/file", renameArchivePath("old/file", "old", "new"))
+	assert.EqualValues(t, "older/file", renameArchivePath("older/file", "old", "new"))
+}
+
+// TestRebaseArchive is a function.
+func TestRebaseArchive(t *testing.T) {
+	src := &bytes.Buffer{}
+	tw := tar.NewWriter(src)
+	for _, hdr := range []*tar.Header{
+		{Name: "volume/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "volume/data.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+		{Name: "volume/nested", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "volume/nested/link", Typeflag: tar.TypeLink, Linkname: "volume/data.txt"},
+	} {
+		assert.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte("hello"))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, tw.Close())
+
+	dst := &bytes.Buffer{}
+	assert.NoError(t, rebaseArchive(src, dst, "volume", "."))
+
+	tr := tar.NewReader(dst)
+	names := []string{}
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+		if hdr.Typeflag == tar.TypeLink {
+			assert.EqualValues(t, "./data.txt", hdr.Linkname)
+		}
+	}
+	assert.EqualValues(t, []string{"./", "./data.txt", "./nested/", "./nested/link"}, names)
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/go-units"
+	"github.com/samber/lo"
+)
+
+// BuildCachePruneOptions narrows down which build cache records
+// PruneBuildCache removes. The zero value removes every record not in use.
+type BuildCachePruneOptions struct {
+	// Until, if not empty, only prunes records last used before the given time,
+	// in any of the forms ParseUntil accepts
+	Until string
+	// KeepStorage, if not zero, only prunes the least recently used records
+	// until the cache takes up no more than this many bytes
+	KeepStorage int64
+	// DanglingOnly only prunes the records `docker builder prune` prunes
+	// without --all: those not shared with other records, leaving out
+	// BuildKit's internal and frontend records too
+	DanglingOnly bool
+}
+
+// ParseStorageSize parses a size like '10GB' or '512MB', where units are
+// powers of 1024 like in `docker builder prune --keep-storage`
+func ParseStorageSize(size string) (int64, error) {
+	return units.RAMInBytes(size)
+}
+
+// BuildCache lists the build cache records, most recently used first
+func (c *DockerCommand) BuildCache(ctx context.Context) ([]*build.CacheRecord, error) {
+	du, err := c.Client.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.BuildCacheObject}})
+	if err != nil {
+		return nil, err
+	}
+
+	records := du.BuildCache
+	sort.SliceStable(records, func(i, j int) bool {
+		return buildCacheLastUsed(records[i]).After(buildCacheLastUsed(records[j]))
+	})
+
+	return records, nil
+}
+
+func buildCacheLastUsed(record *build.CacheRecord) time.Time {
+	if record.LastUsedAt != nil {
+		return *record.LastUsedAt
+	}
+	return record.CreatedAt
+}
+
+// BuildCachePrunePreview tells us what PruneBuildCache would remove
+type BuildCachePrunePreview struct {
+	Records []*build.CacheRecord
+	// Size is how much space removing the records frees up. Shared records
+	// aren't counted because other records still use their data.
+	Size int64
+}
+
+// PreviewBuildCachePrune works out which of the given build cache records
+// PruneBuildCache would remove with the given options. BuildKit decides for
+// itself what to remove, so this is our best guess at what it'll do.
+func PreviewBuildCachePrune(records []*build.CacheRecord, options BuildCachePruneOptions, now time.Time) (*BuildCachePrunePreview, error) {
+	var until time.Time
+	if options.Until != "" {
+		var err error
+		until, err = ParseUntil(options.Until, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := lo.Filter(records, func(record *build.CacheRecord, _ int) bool {
+		if record.InUse {
+			return false
+		}
+		if options.DanglingOnly && (record.Shared || record.Type == "internal" || record.Type == "frontend") {
+			return false
+		}
+		return until.IsZero() || buildCacheLastUsed(record).Before(until)
+	})
+
+	if options.KeepStorage > 0 {
+		// BuildKit removes the least recently used records first, stopping once
+		// the cache is small enough
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return buildCacheLastUsed(candidates[i]).Before(buildCacheLastUsed(candidates[j]))
+		})
+
+		total := lo.SumBy(records, buildCacheRecordSize)
+		kept := 0
+		for kept < len(candidates) && total > options.KeepStorage {
+			total -= buildCacheRecordSize(candidates[kept])
+			kept++
+		}
+		candidates = candidates[:kept]
+	}
+
+	return &BuildCachePrunePreview{
+		Records: candidates,
+		Size:    lo.SumBy(candidates, buildCacheRecordSize),
+	}, nil
+}
+
+// buildCacheRecordSize returns the space a record's own data takes up
+func buildCacheRecordSize(record *build.CacheRecord) int64 {
+	if record.Shared {
+		return 0
+	}
+	return record.Size
+}
+
+// PruneBuildCache prunes the build cache records matching the given options,
+// returning what was removed
+func (c *DockerCommand) PruneBuildCache(options BuildCachePruneOptions) (*build.CachePruneReport, error) {
+	pruneOptions := build.CachePruneOptions{
+		// without this docker only prunes dangling records
+		All:     !options.DanglingOnly,
+		Filters: filters.NewArgs(),
+	}
+	if options.Until != "" {
+		pruneOptions.Filters.Add("until", options.Until)
+	}
+	if options.KeepStorage > 0 {
+		// docker 28 replaced keep-storage with reserved-space, but older versions
+		// only understand keep-storage, so we send both
+		pruneOptions.ReservedSpace = options.KeepStorage
+		pruneOptions.KeepStorage = options.KeepStorage //nolint:staticcheck
+	}
+
+	return c.Client.BuildCachePrune(context.Background(), pruneOptions)
+}
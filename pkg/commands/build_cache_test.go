@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPreviewBuildCachePrune is a function.
+func TestPreviewBuildCachePrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	recent := &build.CacheRecord{ID: "recent", Size: 100, LastUsedAt: ago(time.Hour)}
+	old := &build.CacheRecord{ID: "old", Size: 200, LastUsedAt: ago(72 * time.Hour)}
+	oldest := &build.CacheRecord{ID: "oldest", Size: 300, LastUsedAt: ago(96 * time.Hour)}
+	shared := &build.CacheRecord{ID: "shared", Size: 1000, Shared: true, LastUsedAt: ago(48 * time.Hour)}
+	inUse := &build.CacheRecord{ID: "in-use", Size: 50, InUse: true, CreatedAt: now.Add(-200 * time.Hour)}
+
+	records := []*build.CacheRecord{recent, old, oldest, shared, inUse}
+
+	type scenario struct {
+		name            string
+		options         BuildCachePruneOptions
+		expectedRecords []*build.CacheRecord
+		expectedSize    int64
+	}
+
+	scenarios := []scenario{
+		{"all", BuildCachePruneOptions{}, []*build.CacheRecord{recent, old, oldest, shared}, 600},
+		{"dangling only", BuildCachePruneOptions{DanglingOnly: true}, []*build.CacheRecord{recent, old, oldest}, 600},
+		{"until", BuildCachePruneOptions{Until: "24h"}, []*build.CacheRecord{old, oldest, shared}, 500},
+		// 650 bytes in all, so it takes removing the oldest two to get under 300
+		{"keep storage", BuildCachePruneOptions{KeepStorage: 300}, []*build.CacheRecord{oldest, old}, 500},
+		{"keep storage already under", BuildCachePruneOptions{KeepStorage: 1000}, []*build.CacheRecord{}, 0},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			preview, err := PreviewBuildCachePrune(records, s.options, now)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedRecords, preview.Records)
+			assert.EqualValues(t, s.expectedSize, preview.Size)
+		})
+	}
+}
+
+// TestParseStorageSize is a function.
+func TestParseStorageSize(t *testing.T) {
+	size, err := ParseStorageSize("10GB")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10*1024*1024*1024, size)
+
+	_, err = ParseStorageSize("lots")
+	assert.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// BuildKit reports the progress of a build as protobuf encoded StatusResponse
+// messages (see BuildKit's control.proto). We only need a few of their fields,
+// so rather than depending on BuildKit itself we decode them by hand.
+
+var errMalformedBuildStatus = errors.New("malformed BuildKit status message")
+
+// BuildStatus is an update on the progress of a BuildKit build
+type BuildStatus struct {
+	Vertexes []BuildVertex
+	Statuses []BuildVertexStatus
+	Logs     []BuildVertexLog
+}
+
+// BuildVertex is a step of a BuildKit build, e.g. '[2/3] RUN make'
+type BuildVertex struct {
+	Digest    string
+	Name      string
+	Cached    bool
+	Started   *time.Time
+	Completed *time.Time
+	Error     string
+}
+
+// BuildVertexStatus is the progress of some part of a step, e.g. pulling one of
+// the layers of a base image
+type BuildVertexStatus struct {
+	ID        string
+	Vertex    string
+	Name      string
+	Current   int64
+	Total     int64
+	Completed bool
+}
+
+// BuildVertexLog is some output of a step
+type BuildVertexLog struct {
+	Vertex string
+	Msg    []byte
+}
+
+type protoField struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+func decodeBuildStatus(data []byte) (BuildStatus, error) {
+	status := BuildStatus{}
+
+	fields, err := readProtoFields(data)
+	if err != nil {
+		return status, err
+	}
+
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			vertex, err := decodeBuildVertex(field.bytes)
+			if err != nil {
+				return status, err
+			}
+			status.Vertexes = append(status.Vertexes, vertex)
+		case 2:
+			vertexStatus, err := decodeBuildVertexStatus(field.bytes)
+			if err != nil {
+				return status, err
+			}
+			status.Statuses = append(status.Statuses, vertexStatus)
+		case 3:
+			log, err := decodeBuildVertexLog(field.bytes)
+			if err != nil {
+				return status, err
+			}
+			status.Logs = append(status.Logs, log)
+		}
+	}
+
+	return status, nil
+}
+
+func decodeBuildVertex(data []byte) (BuildVertex, error) {
+	vertex := BuildVertex{}
+
+	fields, err := readProtoFields(data)
+	if err != nil {
+		return vertex, err
+	}
+
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			vertex.Digest = string(field.bytes)
+		case 3:
+			vertex.Name = string(field.bytes)
+		case 4:
+			vertex.Cached = field.varint != 0
+		case 5:
+			if vertex.Started, err = decodeProtoTimestamp(field.bytes); err != nil {
+				return vertex, err
+			}
+		case 6:
+			if vertex.Completed, err = decodeProtoTimestamp(field.bytes); err != nil {
+				return vertex, err
+			}
+		case 7:
+			vertex.Error = string(field.bytes)
+		}
+	}
+
+	return vertex, nil
+}
+
+func decodeBuildVertexStatus(data []byte) (BuildVertexStatus, error) {
+	status := BuildVertexStatus{}
+
+	fields, err := readProtoFields(data)
+	if err != nil {
+		return status, err
+	}
+
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			status.ID = string(field.bytes)
+		case 2:
+			status.Vertex = string(field.bytes)
+		case 3:
+			status.Name = string(field.bytes)
+		case 4:
+			status.Current = int64(field.varint)
+		case 5:
+			status.Total = int64(field.varint)
+		case 8:
+			status.Completed = true
+		}
+	}
+
+	return status, nil
+}
+
+func decodeBuildVertexLog(data []byte) (BuildVertexLog, error) {
+	log := BuildVertexLog{}
+
+	fields, err := readProtoFields(data)
+	if err != nil {
+		return log, err
+	}
+
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			log.Vertex = string(field.bytes)
+		case 4:
+			log.Msg = field.bytes
+		}
+	}
+
+	return log, nil
+}
+
+func decodeProtoTimestamp(data []byte) (*time.Time, error) {
+	fields, err := readProtoFields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var seconds, nanos int64
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			seconds = int64(field.varint)
+		case 2:
+			nanos = int64(field.varint)
+		}
+	}
+
+	t := time.Unix(seconds, nanos)
+	return &t, nil
+}
+
+// readProtoFields splits a protobuf message into its fields. We keep the value
+// of varint and length-delimited fields (which covers strings, bytes and nested
+// messages) and skip over fixed-size ones.
+func readProtoFields(data []byte) ([]protoField, error) {
+	fields := []protoField{}
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errMalformedBuildStatus
+		}
+		data = data[n:]
+
+		field := protoField{num: int(key >> 3)}
+		switch key & 7 {
+		case 0: // varint
+			value, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, errMalformedBuildStatus
+			}
+			field.varint = value
+			data = data[n:]
+		case 1: // 64-bit
+			if len(data) < 8 {
+				return nil, errMalformedBuildStatus
+			}
+			data = data[8:]
+		case 2: // length-delimited
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, errMalformedBuildStatus
+			}
+			field.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case 5: // 32-bit
+			if len(data) < 4 {
+				return nil, errMalformedBuildStatus
+			}
+			data = data[4:]
+		default:
+			return nil, errMalformedBuildStatus
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendProtoVarint(data []byte, num int, value uint64) []byte {
+	data = binary.AppendUvarint(data, uint64(num<<3))
+	return binary.AppendUvarint(data, value)
+}
+
+func appendProtoBytes(data []byte, num int, value []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(num<<3|2))
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
+
+// TestDecodeBuildStatus is a function.
+func TestDecodeBuildStatus(t *testing.T) {
+	started := appendProtoVarint(nil, 1, 100)
+	completed := appendProtoVarint(appendProtoVarint(nil, 1, 102), 2, 500000000)
+
+	vertex := appendProtoBytes(nil, 1, []byte("sha256:aaa"))
+	vertex = appendProtoBytes(vertex, 2, []byte("sha256:input"))
+	vertex = appendProtoBytes(vertex, 3, []byte("[2/3] RUN make"))
+	vertex = appendProtoBytes(vertex, 5, started)
+	vertex = appendProtoBytes(vertex, 6, completed)
+	vertex = appendProtoBytes(vertex, 7, []byte("exit code: 2"))
+	// a field we don't know about, which should be skipped
+	vertex = append(binary.AppendUvarint(vertex, uint64(9<<3|1)), 1, 2, 3, 4, 5, 6, 7, 8)
+
+	status := appendProtoBytes(nil, 1, []byte("layer"))
+	status = appendProtoBytes(status, 2, []byte("sha256:aaa"))
+	status = appendProtoVarint(status, 4, 512)
+	status = appendProtoVarint(status, 5, 1024)
+
+	log := appendProtoBytes(nil, 1, []byte("sha256:aaa"))
+	log = appendProtoVarint(log, 3, 1)
+	log = appendProtoBytes(log, 4, []byte("compiling...\n"))
+
+	data := appendProtoBytes(nil, 1, vertex)
+	data = appendProtoBytes(data, 2, status)
+	data = appendProtoBytes(data, 3, log)
+
+	result, err := decodeBuildStatus(data)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Vertexes, 1)
+	assert.EqualValues(t, "sha256:aaa", result.Vertexes[0].Digest)
+	assert.EqualValues(t, "[2/3] RUN make", result.Vertexes[0].Name)
+	assert.EqualValues(t, "exit code: 2", result.Vertexes[0].Error)
+	assert.False(t, result.Vertexes[0].Cached)
+	assert.EqualValues(t, 2.5, result.Vertexes[0].Completed.Sub(*result.Vertexes[0].Started).Seconds())
+
+	assert.EqualValues(t, []BuildVertexStatus{{ID: "layer", Vertex: "sha256:aaa", Current: 512, Total: 1024}}, result.Statuses)
+	assert.EqualValues(t, []BuildVertexLog{{Vertex: "sha256:aaa", Msg: []byte("compiling...\n")}}, result.Logs)
+
+	_, err = decodeBuildStatus(data[:len(data)-3])
+	assert.Error(t, err)
+}
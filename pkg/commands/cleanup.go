@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// CleanupKind is one of the kinds of things a cleanup can prune, like the
+// parts of `docker system prune`
+type CleanupKind int
+
+const (
+	CleanupContainers CleanupKind = iota
+	CleanupNetworks
+	CleanupDanglingImages
+	// images which no container uses, dangling or not. This includes the
+	// dangling images, so a cleanup prunes one or the other.
+	CleanupUnusedImages
+	CleanupVolumes
+	// build cache records which aren't shared with other records, which is
+	// what `docker system prune` prunes
+	CleanupDanglingBuildCache
+	// build cache records not in use, shared or not. Like the unused images,
+	// this includes the dangling ones.
+	CleanupAllBuildCache
+)
+
+// CleanupKinds are the kinds of things a cleanup can prune, in the order it
+// prunes them. Removing containers first means the networks, images and
+// volumes they were using can go too.
+var CleanupKinds = []CleanupKind{
+	CleanupContainers,
+	CleanupNetworks,
+	CleanupDanglingImages,
+	CleanupUnusedImages,
+	CleanupVolumes,
+	CleanupDanglingBuildCache,
+	CleanupAllBuildCache,
+}
+
+// CleanupIncludes maps the kinds which include another kind to that kind, e.g.
+// all unused images include the dangling ones. A cleanup prunes one or the
+// other.
+var CleanupIncludes = map[CleanupKind]CleanupKind{
+	CleanupUnusedImages:  CleanupDanglingImages,
+	CleanupAllBuildCache: CleanupDanglingBuildCache,
+}
+
+// CleanupItem is a single thing a cleanup would remove
+type CleanupItem struct {
+	Name string
+	Size int64
+}
+
+// CleanupCandidates are the things of one kind a cleanup would remove
+type CleanupCandidates struct {
+	Kind  CleanupKind
+	Items []CleanupItem
+	// Size is roughly how much space removing the items reclaims. Docker only
+	// tells us how much of each image is unique to it, so for images this is
+	// the least we'd reclaim.
+	Size int64
+}
+
+// CleanupState is what we go by when working out what a cleanup would remove
+type CleanupState struct {
+	Containers []*Container
+	Images     []*Image
+	Networks   []*Network
+	Volumes    []*Volume
+	// DiskUsage has the sizes of the containers, images, volumes and build
+	// cache, which docker only works out when asked
+	DiskUsage types.DiskUsage
+}
+
+// PlanCleanup works out what a cleanup would remove of each kind, in the
+// order of CleanupKinds. If pruneContainers is true, the networks, images and
+// volumes used only by containers the cleanup removes are counted too.
+func PlanCleanup(state *CleanupState, pruneContainers bool, now time.Time) ([]*CleanupCandidates, error) {
+	du := state.DiskUsage
+
+	containers, err := ContainersToPrune(state.Containers, PruneOptions{}, now)
+	if err != nil {
+		return nil, err
+	}
+	containerSizes := lo.SliceToMap(du.Containers, func(ctr *container.Summary) (string, int64) {
+		return ctr.ID, ctr.SizeRw
+	})
+
+	remaining := state.Containers
+	if pruneContainers {
+		remaining = lo.Without(state.Containers, containers...)
+	}
+
+	networks, err := NetworksToPrune(state.Networks, remaining, PruneOptions{}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// the size of each image docker wouldn't reclaim by removing it, because
+	// other images share those layers. It's -1 if docker couldn't work it out.
+	imageSharedSizes := lo.SliceToMap(du.Images, func(img *image.Summary) (string, int64) {
+		return img.ID, img.SharedSize
+	})
+	imageItems := func(options ImagePruneOptions) ([]CleanupItem, error) {
+		images, err := imagesToPrune(state.Images, remaining, options, now)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(images, func(img *Image, _ int) CleanupItem {
+			name := strings.Join(img.RepoTags(), ", ")
+			if name == "" {
+				name = utils.SafeTruncate(strings.TrimPrefix(img.ID, "sha256:"), 12)
+			}
+			size := img.Image.Size
+			if shared, ok := imageSharedSizes[img.ID]; ok && shared >= 0 {
+				size -= shared
+			}
+			return CleanupItem{Name: name, Size: size}
+		}), nil
+	}
+	danglingImages, err := imageItems(ImagePruneOptions{})
+	if err != nil {
+		return nil, err
+	}
+	unusedImages, err := imageItems(ImagePruneOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSizes := lo.SliceToMap(du.Volumes, func(vol *volume.Volume) (string, int64) {
+		if vol.UsageData == nil {
+			return vol.Name, 0
+		}
+		return vol.Name, max(vol.UsageData.Size, 0)
+	})
+	volumes := VolumesToPrune(state.Volumes, remaining, PruneOptions{})
+
+	buildCacheItems := func(options BuildCachePruneOptions) ([]CleanupItem, error) {
+		preview, err := PreviewBuildCachePrune(du.BuildCache, options, now)
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(preview.Records, func(record *build.CacheRecord, _ int) CleanupItem {
+			return CleanupItem{Name: record.Description, Size: buildCacheRecordSize(record)}
+		}), nil
+	}
+	danglingBuildCache, err := buildCacheItems(BuildCachePruneOptions{DanglingOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	allBuildCache, err := buildCacheItems(BuildCachePruneOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := func(kind CleanupKind, items []CleanupItem) *CleanupCandidates {
+		return &CleanupCandidates{
+			Kind:  kind,
+			Items: items,
+			Size:  lo.SumBy(items, func(item CleanupItem) int64 { return item.Size }),
+		}
+	}
+
+	return []*CleanupCandidates{
+		candidates(CleanupContainers, lo.Map(containers, func(ctr *Container, _ int) CleanupItem {
+			return CleanupItem{Name: ctr.Name, Size: containerSizes[ctr.ID]}
+		})),
+		candidates(CleanupNetworks, lo.Map(networks, func(network *Network, _ int) CleanupItem {
+			return CleanupItem{Name: network.Name}
+		})),
+		candidates(CleanupDanglingImages, danglingImages),
+		candidates(CleanupUnusedImages, unusedImages),
+		candidates(CleanupVolumes, lo.Map(volumes, func(volume *Volume, _ int) CleanupItem {
+			return CleanupItem{Name: volume.Name, Size: volumeSizes[volume.Name]}
+		})),
+		candidates(CleanupDanglingBuildCache, danglingBuildCache),
+		candidates(CleanupAllBuildCache, allBuildCache),
+	}, nil
+}
+
+// CleanupResult is what a cleanup removed of one kind
+type CleanupResult struct {
+	Kind           CleanupKind
+	Removed        []string
+	SpaceReclaimed uint64
+	// Err is why we couldn't prune things of this kind, if we couldn't
+	Err error
+}
+
+// Cleanup prunes the given kinds of things, in the order of CleanupKinds,
+// like `docker system prune`. It carries on if one of the prunes fails,
+// returning what each of them removed. If given both a kind and one it
+// includes (see CleanupIncludes), it only prunes the former.
+func (c *DockerCommand) Cleanup(kinds []CleanupKind) []*CleanupResult {
+	for kind, included := range CleanupIncludes {
+		if lo.Contains(kinds, kind) {
+			kinds = lo.Without(kinds, included)
+		}
+	}
+
+	results := []*CleanupResult{}
+	for _, kind := range CleanupKinds {
+		if !lo.Contains(kinds, kind) {
+			continue
+		}
+
+		result := &CleanupResult{Kind: kind}
+		switch kind {
+		case CleanupContainers:
+			report, err := c.PruneContainers(PruneOptions{})
+			result.Removed, result.SpaceReclaimed, result.Err = report.ContainersDeleted, report.SpaceReclaimed, err
+		case CleanupNetworks:
+			report, err := c.PruneNetworks(PruneOptions{})
+			result.Removed, result.Err = report.NetworksDeleted, err
+		case CleanupDanglingImages, CleanupUnusedImages:
+			report, err := c.PruneImages(ImagePruneOptions{All: kind == CleanupUnusedImages})
+			result.SpaceReclaimed, result.Err = report.SpaceReclaimed, err
+			// untagging an image also gets an entry in the report, so we only
+			// count the images that were actually deleted
+			result.Removed = lo.FilterMap(report.ImagesDeleted, func(item image.DeleteResponse, _ int) (string, bool) {
+				return item.Deleted, item.Deleted != ""
+			})
+		case CleanupVolumes:
+			report, err := c.PruneVolumes(PruneOptions{})
+			result.Removed, result.SpaceReclaimed, result.Err = report.VolumesDeleted, report.SpaceReclaimed, err
+		case CleanupDanglingBuildCache, CleanupAllBuildCache:
+			report, err := c.PruneBuildCache(BuildCachePruneOptions{DanglingOnly: kind == CleanupDanglingBuildCache})
+			if report != nil {
+				result.Removed, result.SpaceReclaimed = report.CachesDeleted, report.SpaceReclaimed
+			}
+			result.Err = err
+		}
+
+		if result.Err != nil {
+			c.Log.Error(result.Err)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPlanCleanup is a function.
+func TestPlanCleanup(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// the stopped container is the only thing using the network, the tagged
+	// image and the volume
+	stopped := &Container{ID: "stopped-id", Name: "stopped", Container: container.Summary{
+		ID:      "stopped-id",
+		State:   "exited",
+		ImageID: "sha256:tagged",
+		Mounts:  []container.MountPoint{{Type: mount.TypeVolume, Name: "data"}},
+		NetworkSettings: &container.NetworkSettingsSummary{
+			Networks: map[string]*network.EndpointSettings{"app": {}},
+		},
+	}}
+	running := &Container{ID: "running-id", Name: "running", Container: container.Summary{
+		ID:      "running-id",
+		State:   "running",
+		ImageID: "sha256:in-use",
+	}}
+
+	state := &CleanupState{
+		Containers: []*Container{stopped, running},
+		Images: []*Image{
+			{ID: "sha256:dangling", Image: image.Summary{ID: "sha256:dangling", Size: 100}},
+			{ID: "sha256:tagged", Image: image.Summary{ID: "sha256:tagged", RepoTags: []string{"app:1"}, Size: 300}},
+			{ID: "sha256:in-use", Image: image.Summary{ID: "sha256:in-use", RepoTags: []string{"db:1"}, Size: 500}},
+		},
+		Networks: []*Network{
+			{Name: "bridge", Network: network.Inspect{Name: "bridge"}},
+			{Name: "app", Network: network.Inspect{Name: "app"}},
+		},
+		Volumes: []*Volume{
+			{Name: "data", Volume: &volume.Volume{Name: "data"}},
+		},
+		DiskUsage: types.DiskUsage{
+			Containers: []*container.Summary{{ID: "stopped-id", SizeRw: 10}, {ID: "running-id", SizeRw: 20}},
+			Images: []*image.Summary{
+				{ID: "sha256:dangling", SharedSize: 40},
+				{ID: "sha256:tagged", SharedSize: -1},
+			},
+			Volumes: []*volume.Volume{{Name: "data", UsageData: &volume.UsageData{Size: 1000}}},
+			BuildCache: []*build.CacheRecord{
+				{ID: "a", Description: "RUN make", Size: 50},
+				{ID: "b", Description: "shared", Size: 70, Shared: true},
+				{ID: "c", Description: "in use", Size: 90, InUse: true},
+				{ID: "d", Description: "internal", Size: 30, Type: "internal"},
+			},
+		},
+	}
+
+	type scenario struct {
+		name            string
+		pruneContainers bool
+		expectedNames   map[CleanupKind][]string
+		expectedSizes   map[CleanupKind]int64
+	}
+
+	scenarios := []scenario{
+		{
+			name:            "keeping the stopped container",
+			pruneContainers: false,
+			expectedNames: map[CleanupKind][]string{
+				CleanupContainers:         {"stopped"},
+				CleanupNetworks:           {},
+				CleanupDanglingImages:     {"dangling"},
+				CleanupUnusedImages:       {"dangling"},
+				CleanupVolumes:            {},
+				CleanupDanglingBuildCache: {"RUN make"},
+				CleanupAllBuildCache:      {"RUN make", "shared", "internal"},
+			},
+			expectedSizes: map[CleanupKind]int64{
+				CleanupContainers:         10,
+				CleanupNetworks:           0,
+				CleanupDanglingImages:     60,
+				CleanupUnusedImages:       60,
+				CleanupVolumes:            0,
+				CleanupDanglingBuildCache: 50,
+				CleanupAllBuildCache:      80,
+			},
+		},
+		{
+			name:            "removing the stopped container",
+			pruneContainers: true,
+			expectedNames: map[CleanupKind][]string{
+				CleanupContainers:         {"stopped"},
+				CleanupNetworks:           {"app"},
+				CleanupDanglingImages:     {"dangling"},
+				CleanupUnusedImages:       {"dangling", "app:1"},
+				CleanupVolumes:            {"data"},
+				CleanupDanglingBuildCache: {"RUN make"},
+				CleanupAllBuildCache:      {"RUN make", "shared", "internal"},
+			},
+			expectedSizes: map[CleanupKind]int64{
+				CleanupContainers:         10,
+				CleanupNetworks:           0,
+				CleanupDanglingImages:     60,
+				CleanupUnusedImages:       360,
+				CleanupVolumes:            1000,
+				CleanupDanglingBuildCache: 50,
+				CleanupAllBuildCache:      80,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			plan, err := PlanCleanup(state, s.pruneContainers, now)
+			assert.NoError(t, err)
+			assert.EqualValues(t, CleanupKinds, lo.Map(plan, func(candidates *CleanupCandidates, _ int) CleanupKind {
+				return candidates.Kind
+			}))
+
+			for _, candidates := range plan {
+				names := lo.Map(candidates.Items, func(item CleanupItem, _ int) string { return item.Name })
+				assert.EqualValues(t, s.expectedNames[candidates.Kind], names, candidates.Kind)
+				assert.EqualValues(t, s.expectedSizes[candidates.Kind], candidates.Size, candidates.Kind)
+			}
+		})
+	}
+}
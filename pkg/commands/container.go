@@ -3,11 +3,9 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/go-errors/errors"
 	"github.com/peauc/lazydocker-ng/pkg/i18n"
@@ -87,25 +85,23 @@ func (c *Container) Restart() error {
 	return c.Client.ContainerRestart(context.Background(), c.ID, container.StopOptions{})
 }
 
-// Attach attaches the container
-func (c *Container) Attach() (*exec.Cmd, error) {
+// CanAttach tells us whether we are able to attach to the container, returning
+// an error explaining why not if we can't
+func (c *Container) CanAttach() error {
 	if !c.DetailsLoaded() {
-		return nil, errors.New(c.Tr.WaitingForContainerInfo)
+		return errors.New(c.Tr.WaitingForContainerInfo)
 	}
 
 	// verify that we can in fact attach to this container
 	if !c.Details.Config.OpenStdin {
-		return nil, errors.New(c.Tr.UnattachableContainerError)
+		return errors.New(c.Tr.UnattachableContainerError)
 	}
 
 	if c.Container.State == "exited" {
-		return nil, errors.New(c.Tr.CannotAttachStoppedContainerError)
+		return errors.New(c.Tr.CannotAttachStoppedContainerError)
 	}
 
-	c.Log.Warn(fmt.Sprintf("attaching to container %s", c.Name))
-	// TODO: use SDK
-	cmd := c.OSCommand.NewCmd("docker", "attach", "--sig-proxy=false", c.ID)
-	return cmd, nil
+	return nil
 }
 
 // Top returns process information
@@ -123,10 +119,10 @@ func (c *Container) Top(ctx context.Context) (container.TopResponse, error) {
 	return c.Client.ContainerTop(ctx, c.ID, []string{})
 }
 
-// PruneContainers prunes containers
-func (c *DockerCommand) PruneContainers() error {
-	_, err := c.Client.ContainersPrune(context.Background(), filters.Args{})
-	return err
+// Diff returns the changes made to the container's filesystem since it was
+// created
+func (c *Container) Diff(ctx context.Context) ([]container.FilesystemChange, error) {
+	return c.Client.ContainerDiff(ctx, c.ID)
 }
 
 // Inspect returns details about the container
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moby/term"
+)
+
+// Attach hands the given terminal over to the container's main process until
+// the user presses the configured detach key sequence or the container exits.
+// Output is demuxed into stdout and stderr for containers without a TTY.
+// Cancelling ctx ends the session as if the user had detached.
+func (c *Container) Attach(ctx context.Context, stdin *os.File, stdout *os.File, stderr io.Writer) error {
+	if err := c.CanAttach(); err != nil {
+		return err
+	}
+
+	c.Log.Warn(fmt.Sprintf("attaching to container %s", c.Name))
+
+	detachKeys := c.OSCommand.Config.UserConfig.Attach.DetachKeys
+	escapeKeys, err := term.ToBytes(detachKeys)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp, err := c.Client.ContainerAttach(ctx, c.ID, container.AttachOptions{
+		Stream:     true,
+		Stdin:      true,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: detachKeys,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	tty := c.Details.Config.Tty
+	if tty && term.IsTerminal(stdin.Fd()) {
+		state, err := term.MakeRaw(stdin.Fd())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := term.RestoreTerminal(stdin.Fd(), state); err != nil {
+				c.Log.Error(err)
+			}
+		}()
+
+		c.resizeTTY(ctx, stdout)
+		go monitorTTYSize(ctx, stdout, func() { c.resizeTTY(ctx, stdout) })
+	}
+
+	input, err := newCancellableReader(stdin)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	outputDone := make(chan error, 1)
+	go func() {
+		var err error
+		if tty {
+			_, err = io.Copy(stdout, resp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+		outputDone <- err
+	}()
+
+	// the daemon also watches for the detach keys, but we check for them on our
+	// end so that we stop reading from the terminal straight away
+	detached := make(chan struct{})
+	go func() {
+		_, err := io.Copy(resp.Conn, term.NewEscapeProxy(input, escapeKeys))
+		if errors.As(err, &term.EscapeError{}) {
+			close(detached)
+			return
+		}
+		_ = resp.CloseWrite()
+	}()
+
+	select {
+	case <-detached:
+		return nil
+	case <-ctx.Done():
+		return nil
+	case err := <-outputDone:
+		return err
+	}
+}
+
+// resizeTTY resizes the container's TTY to match the size of our terminal
+func (c *Container) resizeTTY(ctx context.Context, out *os.File) {
+	size, err := term.GetWinsize(out.Fd())
+	if err != nil || size.Height == 0 || size.Width == 0 {
+		return
+	}
+
+	if err := c.Client.ContainerResize(ctx, c.ID, container.ResizeOptions{
+		Height: uint(size.Height),
+		Width:  uint(size.Width),
+	}); err != nil {
+		c.Log.Error(err)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build !windows
+
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// monitorTTYSize calls resize whenever our terminal is resized, until the
+// context is cancelled
+func monitorTTYSize(ctx context.Context, _ *os.File, resize func()) {
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, syscall.SIGWINCH)
+	defer signal.Stop(sigchan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigchan:
+			resize()
+		}
+	}
+}
+
+// cancellableReader reads from a non-blocking duplicate of a terminal's file
+// descriptor, meaning that closing it interrupts any pending read. Without this,
+// a read left over from an attach session would swallow the next keypress
+// meant for the gui.
+type cancellableReader struct {
+	*os.File
+	original *os.File
+}
+
+func newCancellableReader(f *os.File) (io.ReadCloser, error) {
+	fd, err := syscall.Dup(int(f.Fd()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		_ = syscall.Close(fd)
+		return nil, err
+	}
+
+	return &cancellableReader{File: os.NewFile(uintptr(fd), f.Name()), original: f}, nil
+}
+
+func (r *cancellableReader) Close() error {
+	err := r.File.Close()
+
+	// the non-blocking flag is shared with the original file descriptor, so we
+	// need to unset it again for whoever reads from the terminal next
+	if nonblockErr := syscall.SetNonblock(int(r.original.Fd()), false); err == nil {
+		err = nonblockErr
+	}
+
+	return err
+}
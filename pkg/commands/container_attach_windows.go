@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/moby/term"
+)
+
+// monitorTTYSize calls resize whenever our terminal is resized, until the
+// context is cancelled. Windows has no SIGWINCH so we poll instead.
+func monitorTTYSize(ctx context.Context, out *os.File, resize func()) {
+	prevSize, _ := term.GetWinsize(out.Fd())
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			size, err := term.GetWinsize(out.Fd())
+			if err != nil {
+				continue
+			}
+			if prevSize == nil || *size != *prevSize {
+				prevSize = size
+				resize()
+			}
+		}
+	}
+}
+
+// console reads can't be interrupted on windows, so we just read from the
+// terminal directly
+func newCancellableReader(f *os.File) (io.ReadCloser, error) {
+	return io.NopCloser(f), nil
+}
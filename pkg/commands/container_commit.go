@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/samber/lo"
+)
+
+// the Dockerfile instructions that docker lets us apply when committing a container
+var commitChangeInstructions = []string{"CMD", "ENTRYPOINT", "ENV", "EXPOSE", "LABEL", "ONBUILD", "USER", "VOLUME", "WORKDIR"}
+
+// the other Dockerfile instructions, which we recognise only so that we can
+// tell the user they can't be applied
+var otherDockerfileInstructions = []string{"ADD", "ARG", "COPY", "FROM", "HEALTHCHECK", "MAINTAINER", "RUN", "SHELL", "STOPSIGNAL"}
+
+// Commit creates a new image from the container's current state, returning
+// the new image's ID. reference is the image's repository:tag (which can be
+// left empty for an untagged image) and changes are Dockerfile instructions
+// to apply to the image, as returned by ParseCommitChanges.
+func (c *Container) Commit(reference, author, message string, changes []string) (string, error) {
+	c.Log.Warn(fmt.Sprintf("committing container %s to image %s", c.Name, reference))
+
+	response, err := c.Client.ContainerCommit(context.Background(), c.ID, container.CommitOptions{
+		Reference: reference,
+		Author:    author,
+		Comment:   message,
+		Changes:   changes,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return response.ID, nil
+}
+
+// ParseCommitChanges splits a ';'-separated list of Dockerfile instructions
+// e.g. `CMD ["sh"]; ENV FOO=bar; EXPOSE 80` into the changes we pass to
+// Commit, returning an error for any instruction docker won't accept. A ';'
+// in quotes, or which isn't followed by an instruction, is part of the change,
+// so that shell-form changes like `CMD make; make install` survive.
+func ParseCommitChanges(str string) ([]string, error) {
+	changes := []string{}
+	for _, change := range splitCommitChanges(str) {
+		change = strings.TrimSpace(change)
+		if change == "" {
+			continue
+		}
+
+		instruction := strings.ToUpper(strings.Fields(change)[0])
+		if !lo.Contains(commitChangeInstructions, instruction) {
+			return nil, fmt.Errorf("unsupported instruction '%s'. Expected one of %s", instruction, strings.Join(commitChangeInstructions, ", "))
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func splitCommitChanges(str string) []string {
+	changes := []string{}
+	start := 0
+	var quote rune
+	escaped := false
+	for i, r := range str {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ';' && startsWithInstruction(str[i+1:]):
+			changes = append(changes, str[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(changes, str[start:])
+}
+
+// startsWithInstruction tells whether str is blank or starts with a Dockerfile
+// instruction, i.e. whether a ';' before it ends a change
+func startsWithInstruction(str string) bool {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return true
+	}
+
+	instruction := strings.ToUpper(fields[0])
+	return lo.Contains(commitChangeInstructions, instruction) || lo.Contains(otherDockerfileInstructions, instruction)
+}
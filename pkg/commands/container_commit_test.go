@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCommitChanges is a function.
+func TestParseCommitChanges(t *testing.T) {
+	type scenario struct {
+		input    string
+		expected []string
+		err      bool
+	}
+
+	scenarios := []scenario{
+		{
+			"",
+			[]string{},
+			false,
+		},
+		{
+			`CMD ["sh", "-c", "sleep 1"]; env FOO=bar ;EXPOSE 80;`,
+			[]string{`CMD ["sh", "-c", "sleep 1"]`, "env FOO=bar", "EXPOSE 80"},
+			false,
+		},
+		{
+			`CMD make; make install; ENTRYPOINT sh -c "x; y"; LABEL note='a;b'`,
+			[]string{"CMD make; make install", `ENTRYPOINT sh -c "x; y"`, "LABEL note='a;b'"},
+			false,
+		},
+		{
+			"RUN rm -rf /",
+			nil,
+			true,
+		},
+		{
+			"ENV FOO=bar; RUN rm -rf /",
+			nil,
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		changes, err := ParseCommitChanges(s.input)
+		assert.EqualValues(t, s.expected, changes)
+		if s.err {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// StatPath returns information about a path inside the container. Relative
+// paths are taken to be relative to the container's root directory, as with
+// `docker cp`.
+func (c *Container) StatPath(containerPath string) (container.PathStat, error) {
+	return c.Client.ContainerStatPath(context.Background(), c.ID, absContainerPath(containerPath))
+}
+
+// PathExists tells us whether the given path exists inside the container
+func (c *Container) PathExists(containerPath string) bool {
+	_, err := c.StatPath(containerPath)
+	return err == nil
+}
+
+// ListPath lists everything that copying the given path out of the container
+// would copy. Note that docker has to send us the contents of every file too,
+// so this can take a while for big directories.
+func (c *Container) ListPath(ctx context.Context, containerPath string) ([]ArchiveEntry, error) {
+	reader, _, err := c.Client.CopyFromContainer(ctx, c.ID, absContainerPath(containerPath))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return readArchiveEntries(reader)
+}
+
+// ReadFile returns up to limit bytes of a regular file inside the container,
+// along with whether the file was truncated
+func (c *Container) ReadFile(ctx context.Context, containerPath string, limit int64) ([]byte, bool, error) {
+	reader, stat, err := c.Client.CopyFromContainer(ctx, c.ID, absContainerPath(containerPath))
+	if err != nil {
+		return nil, false, err
+	}
+	defer reader.Close()
+
+	if !stat.Mode.IsRegular() {
+		return nil, false, fmt.Errorf("%s is not a regular file", containerPath)
+	}
+
+	return readArchiveFile(reader, limit)
+}
+
+// CopyDestination returns the path in the container that copying srcPath from
+// the host to dstPath will produce. Like `docker cp`, if dstPath is an existing
+// directory we copy into it, otherwise we copy to dstPath itself.
+func (c *Container) CopyDestination(srcPath, dstPath string) string {
+	dstPath = absContainerPath(dstPath)
+	if stat, err := c.StatPath(dstPath); err == nil && stat.Mode.IsDir() {
+		return path.Join(dstPath, filepath.Base(srcPath))
+	}
+	return dstPath
+}
+
+// HostCopyDestination returns the host path that copying srcPath out of a
+// container to dstPath will produce. Like `docker cp`, if dstPath is an
+// existing directory we copy into it, otherwise we copy to dstPath itself.
+func HostCopyDestination(srcPath, dstPath string) string {
+	if info, err := os.Stat(dstPath); err == nil && info.IsDir() {
+		return filepath.Join(dstPath, path.Base(absContainerPath(srcPath)))
+	}
+	return dstPath
+}
+
+// ListHostPath lists everything that copying the given host path into a
+// container would copy
+func ListHostPath(srcPath string) ([]ArchiveEntry, error) {
+	return listHostPath(srcPath)
+}
+
+// CopyFromContainer copies srcPath out of the container to dstPath on the
+// host. onProgress is called with the number of bytes received so far.
+func (c *Container) CopyFromContainer(srcPath, dstPath string, onProgress func(int64)) error {
+	c.Log.Warn(fmt.Sprintf("copying %s from container %s to %s", srcPath, c.Name, dstPath))
+
+	reader, stat, err := c.Client.CopyFromContainer(context.Background(), c.ID, absContainerPath(srcPath))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	target, err := filepath.Abs(HostCopyDestination(srcPath, dstPath))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	return extractArchive(newProgressReader(reader, onProgress), filepath.Dir(target), stat.Name, filepath.Base(target))
+}
+
+// CopyToContainer copies srcPath on the host to dstPath in the container.
+// onProgress is called with the number of bytes sent so far.
+func (c *Container) CopyToContainer(srcPath, dstPath string, onProgress func(int64)) error {
+	c.Log.Warn(fmt.Sprintf("copying %s to %s in container %s", srcPath, dstPath, c.Name))
+
+	if _, err := os.Lstat(srcPath); err != nil {
+		return err
+	}
+
+	target := c.CopyDestination(srcPath, dstPath)
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		writer.CloseWithError(createArchive(writer, srcPath, path.Base(target), nil))
+	}()
+
+	return c.Client.CopyToContainer(
+		context.Background(),
+		c.ID,
+		path.Dir(target),
+		newProgressReader(reader, onProgress),
+		container.CopyToContainerOptions{},
+	)
+}
+
+func absContainerPath(containerPath string) string {
+	return path.Join("/", containerPath)
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// listDirScript lists the entries directly inside the directory given as its
+// first argument, as NUL-separated records of the entry's raw mode (in hex)
+// and size, its name, and the target if it's a symlink. It only needs a shell
+// and stat, which busybox gives us as well as coreutils.
+const listDirScript = `
+command -v stat >/dev/null || exit 127
+cd -- "$1" || exit 1
+for f in * .[!.]* ..?*; do
+	[ -e "$f" ] || [ -L "$f" ] || continue
+	target=
+	if [ -L "$f" ]; then target=$(readlink -- "$f"); fi
+	printf '%s\0%s\0%s\0' "$(stat -c '%f %s' -- "$f")" "$f" "$target"
+done
+`
+
+// ListDir lists the entries directly inside a directory in the container, with
+// each entry's path being its name. If the container is running we list the
+// directory with a command inside it. Otherwise, or if the container has no
+// shell to run it with, we have to copy the directory out of the container,
+// which means docker sends us everything beneath it.
+func (c *Container) ListDir(ctx context.Context, dir string) ([]ArchiveEntry, error) {
+	if c.Container.State == "running" {
+		entries, err := c.execListDir(ctx, dir)
+		if err == nil || ctx.Err() != nil {
+			return entries, err
+		}
+		c.Log.Warn(fmt.Sprintf("could not list %s in container %s, copying it instead: %v", dir, c.Name, err))
+	}
+
+	return c.copyListDir(ctx, dir)
+}
+
+func (c *Container) execListDir(ctx context.Context, dir string) ([]ArchiveEntry, error) {
+	output, err := c.exec(ctx, []string{"sh", "-c", listDirScript, "sh", absContainerPath(dir)})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDirListing(output)
+}
+
+// copyListDir lists a directory from the archive we get by copying it out of
+// the container, leaving out everything beneath its entries
+func (c *Container) copyListDir(ctx context.Context, dir string) ([]ArchiveEntry, error) {
+	entries, err := c.ListPath(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	// the first entry is the directory itself, and every other entry's path
+	// starts with its name
+	rootName := entries[0].Path
+	children := []ArchiveEntry{}
+	for _, entry := range entries[1:] {
+		name := relativeArchivePath(rootName, entry.Path)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		entry.Path = name
+		children = append(children, entry)
+	}
+
+	return children, nil
+}
+
+// relativeArchivePath returns an entry's path relative to the copied path,
+// whose own entry is named rootName. Depending on the copied path, docker may
+// name the root '/' or '.', in which case there's no common prefix to trim.
+func relativeArchivePath(rootName string, name string) string {
+	name = path.Clean(name)
+	if rootName == "/" || rootName == "." {
+		return strings.TrimPrefix(strings.TrimPrefix(name, "./"), "/")
+	}
+
+	if name == rootName {
+		return ""
+	}
+	return strings.TrimPrefix(name, rootName+"/")
+}
+
+// exec runs a command inside the container, returning what it wrote to stdout.
+// If the command fails, the error has what it wrote to stderr.
+func (c *Container) exec(ctx context.Context, cmd []string) ([]byte, error) {
+	created, err := c.Client.ContainerExecCreate(ctx, c.ID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, resp.Reader); err != nil {
+		return nil, err
+	}
+
+	inspect, err := c.Client.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.ExitCode != 0 {
+		return nil, fmt.Errorf("%s exited with code %d: %s", cmd[0], inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// parseDirListing parses the output of listDirScript
+func parseDirListing(output []byte) ([]ArchiveEntry, error) {
+	fields := strings.Split(string(output), "\x00")
+	// the output ends with a NUL, leaving an empty field at the end
+	fields = fields[:len(fields)-1]
+	if len(fields)%3 != 0 {
+		return nil, fmt.Errorf("unexpected directory listing: %q", output)
+	}
+
+	entries := make([]ArchiveEntry, 0, len(fields)/3)
+	for i := 0; i < len(fields); i += 3 {
+		rawMode, rawSize, ok := strings.Cut(fields[i], " ")
+		if !ok {
+			return nil, fmt.Errorf("unexpected directory listing entry: %q", fields[i])
+		}
+		mode, err := strconv.ParseUint(rawMode, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.ParseInt(rawSize, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, ArchiveEntry{
+			Path:       fields[i+1],
+			Size:       size,
+			Mode:       unixFileMode(uint32(mode)),
+			LinkTarget: fields[i+2],
+		})
+	}
+
+	return entries, nil
+}
+
+// unixFileMode converts a file's mode as stat gives it to us into Go's
+// representation
+func unixFileMode(mode uint32) os.FileMode {
+	fileMode := os.FileMode(mode & 0o777)
+
+	switch mode & 0o170000 {
+	case 0o040000:
+		fileMode |= os.ModeDir
+	case 0o120000:
+		fileMode |= os.ModeSymlink
+	case 0o010000:
+		fileMode |= os.ModeNamedPipe
+	case 0o140000:
+		fileMode |= os.ModeSocket
+	case 0o020000:
+		fileMode |= os.ModeDevice | os.ModeCharDevice
+	case 0o060000:
+		fileMode |= os.ModeDevice
+	}
+
+	if mode&0o4000 != 0 {
+		fileMode |= os.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		fileMode |= os.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		fileMode |= os.ModeSticky
+	}
+
+	return fileMode
+}
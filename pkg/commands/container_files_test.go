@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseDirListing is a function.
+func TestParseDirListing(t *testing.T) {
+	output := "41ed 4096\x00nginx\x00\x00" +
+		"81a4 10\x00hosts\x00\x00" +
+		"a1ff 7\x00localtime\x00/usr/share/zoneinfo/UTC\x00" +
+		"81a4 3\x00new\nline\x00\x00"
+
+	entries, err := parseDirListing([]byte(output))
+	assert.NoError(t, err)
+	assert.EqualValues(t, []ArchiveEntry{
+		{Path: "nginx", Size: 4096, Mode: os.ModeDir | 0o755},
+		{Path: "hosts", Size: 10, Mode: 0o644},
+		{Path: "localtime", Size: 7, Mode: os.ModeSymlink | 0o777, LinkTarget: "/usr/share/zoneinfo/UTC"},
+		{Path: "new\nline", Size: 3, Mode: 0o644},
+	}, entries)
+
+	entries, err = parseDirListing(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = parseDirListing([]byte("81a4 10\x00hosts\x00"))
+	assert.Error(t, err)
+}
+
+// TestRelativeArchivePath is a function.
+func TestRelativeArchivePath(t *testing.T) {
+	assert.EqualValues(t, "", relativeArchivePath("etc", "etc"))
+	assert.EqualValues(t, "hosts", relativeArchivePath("etc", "etc/hosts"))
+	assert.EqualValues(t, "nginx/nginx.conf", relativeArchivePath(".", "./nginx/nginx.conf"))
+	assert.EqualValues(t, "etc", relativeArchivePath("/", "/etc"))
+}
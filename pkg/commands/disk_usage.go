@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// DiskUsage breaks down the disk space docker is using, like `docker system df`
+type DiskUsage struct {
+	Images     DiskUsageCategory
+	Containers DiskUsageCategory
+	Volumes    DiskUsageCategory
+	BuildCache DiskUsageCategory
+}
+
+// DiskUsageCategory is the disk space used by one kind of object e.g. images
+type DiskUsageCategory struct {
+	// Count is the number of objects, and Active is how many of them are in use
+	Count  int
+	Active int
+	Size   int64
+	// Reclaimable is how much space we'd free up by removing the objects which
+	// aren't in use
+	Reclaimable int64
+	// Items are the objects themselves, largest first
+	Items []DiskUsageItem
+}
+
+// DiskUsageItem is the disk space used by a single object
+type DiskUsageItem struct {
+	Name  string
+	Size  int64
+	InUse bool
+	// Detail tells us a little more about the object e.g. the image a container
+	// was created from
+	Detail string
+}
+
+// DiskUsage asks docker how much disk space its images, containers, volumes
+// and build cache are using, totalled up like `docker system df`
+func (c *DockerCommand) DiskUsage(ctx context.Context) (*DiskUsage, error) {
+	du, err := c.RawDiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return summariseDiskUsage(du), nil
+}
+
+// RawDiskUsage is DiskUsage as docker reports it, i.e. the size of each
+// object. Docker has to work out the size of every volume to answer, so this
+// can be slow.
+func (c *DockerCommand) RawDiskUsage(ctx context.Context) (types.DiskUsage, error) {
+	du, err := c.Client.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return types.DiskUsage{}, err
+	}
+
+	// we've paid for the volume sizes, so we may as well hang on to them
+	c.VolumeUsageMutex.Lock()
+	c.setVolumeUsage(du.Volumes)
+	c.VolumeUsageMutex.Unlock()
+
+	return du, nil
+}
+
+// volumeUsageTTL is how long we go on showing volume sizes before asking
+// docker for them again
+const volumeUsageTTL = time.Minute
+
+// volumeUsage returns the size and reference count of each volume, which
+// docker only works out when asked for its disk usage. Volumes that docker
+// can't work out the usage of (e.g. those that aren't local) are left out.
+// Docker has to walk every volume to work them out, and we refresh on every
+// docker event, so we only ask once volumeUsageTTL has passed.
+func (c *DockerCommand) volumeUsage() (map[string]*volume.UsageData, error) {
+	c.VolumeUsageMutex.Lock()
+	defer c.VolumeUsageMutex.Unlock()
+
+	if c.cachedVolumeUsage != nil && time.Since(c.volumeUsageFetchedAt) < volumeUsageTTL {
+		return c.cachedVolumeUsage, nil
+	}
+
+	du, err := c.Client.DiskUsage(context.Background(), types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, err
+	}
+
+	c.setVolumeUsage(du.Volumes)
+
+	return c.cachedVolumeUsage, nil
+}
+
+// setVolumeUsage caches the usage docker reported for the given volumes. The
+// caller must hold VolumeUsageMutex.
+func (c *DockerCommand) setVolumeUsage(volumes []*volume.Volume) {
+	usage := make(map[string]*volume.UsageData, len(volumes))
+	for _, vol := range volumes {
+		// docker reports a size of -1 when it doesn't know the size
+		if vol.UsageData != nil && vol.UsageData.Size >= 0 {
+			usage[vol.Name] = vol.UsageData
+		}
+	}
+
+	c.cachedVolumeUsage = usage
+	c.volumeUsageFetchedAt = time.Now()
+}
+
+// summariseDiskUsage works out the totals the same way `docker system df` does
+func summariseDiskUsage(du types.DiskUsage) *DiskUsage {
+	result := &DiskUsage{}
+
+	var imagesInUse int64
+	for _, img := range du.Images {
+		inUse := img.Containers > 0
+		name := strings.Join(lo.Filter(img.RepoTags, func(tag string, _ int) bool { return tag != "<none>:<none>" }), ", ")
+		if name == "" {
+			name = "<none>"
+		}
+		result.Images.add(DiskUsageItem{Name: name, Size: img.Size, InUse: inUse, Detail: utils.SafeTruncate(strings.TrimPrefix(img.ID, "sha256:"), 12)})
+
+		// the layers an image in use shares with other images may still be
+		// reclaimed if those other images aren't in use, so we only count what's
+		// unique to the image
+		if inUse && img.SharedSize >= 0 {
+			imagesInUse += img.Size - img.SharedSize
+		}
+	}
+	// the images share layers, so their sizes add up to more than the space they
+	// take up
+	result.Images.Size = du.LayersSize
+	result.Images.Reclaimable = max(du.LayersSize-imagesInUse, 0)
+
+	for _, ctr := range du.Containers {
+		inUse := ctr.State == "running"
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+		result.Containers.add(DiskUsageItem{Name: name, Size: ctr.SizeRw, InUse: inUse, Detail: ctr.Image})
+		if !inUse {
+			result.Containers.Reclaimable += ctr.SizeRw
+		}
+	}
+
+	for _, vol := range du.Volumes {
+		size, inUse := int64(0), false
+		if vol.UsageData != nil {
+			size = max(vol.UsageData.Size, 0)
+			inUse = vol.UsageData.RefCount > 0
+		}
+		result.Volumes.add(DiskUsageItem{Name: vol.Name, Size: size, InUse: inUse, Detail: vol.Driver})
+		if !inUse {
+			result.Volumes.Reclaimable += size
+		}
+	}
+
+	// like docker, we leave shared cache records out of the total as they're
+	// counted elsewhere
+	for _, record := range du.BuildCache {
+		result.BuildCache.add(DiskUsageItem{Name: record.Description, Size: record.Size, InUse: record.InUse, Detail: record.Type})
+		if record.Shared {
+			result.BuildCache.Size -= record.Size
+		}
+		if !record.InUse && !record.Shared {
+			result.BuildCache.Reclaimable += record.Size
+		}
+	}
+
+	for _, category := range []*DiskUsageCategory{&result.Images, &result.Containers, &result.Volumes, &result.BuildCache} {
+		sort.SliceStable(category.Items, func(i, j int) bool {
+			return category.Items[i].Size > category.Items[j].Size
+		})
+	}
+
+	return result
+}
+
+func (c *DiskUsageCategory) add(item DiskUsageItem) {
+	c.Items = append(c.Items, item)
+	c.Count++
+	c.Size += item.Size
+	if item.InUse {
+		c.Active++
+	}
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSummariseDiskUsage is a function.
+func TestSummariseDiskUsage(t *testing.T) {
+	du := types.DiskUsage{
+		LayersSize: 300,
+		Images: []*image.Summary{
+			{ID: "sha256:0123456789abcdef", RepoTags: []string{"app:1", "app:latest"}, Size: 200, SharedSize: 50, Containers: 1},
+			{ID: "sha256:fedcba9876543210", RepoTags: []string{"<none>:<none>"}, Size: 150, SharedSize: 50, Containers: 0},
+		},
+		Containers: []*container.Summary{
+			{ID: "aaa", Names: []string{"/web"}, Image: "app:1", State: "running", SizeRw: 10},
+			{ID: "bbb", Names: []string{"/old"}, Image: "app:1", State: "exited", SizeRw: 30},
+		},
+		Volumes: []*volume.Volume{
+			{Name: "data", Driver: "local", UsageData: &volume.UsageData{Size: 500, RefCount: 1}},
+			{Name: "orphan", Driver: "local", UsageData: &volume.UsageData{Size: 20, RefCount: 0}},
+			{Name: "remote", Driver: "nfs", UsageData: &volume.UsageData{Size: -1, RefCount: 0}},
+		},
+		BuildCache: []*build.CacheRecord{
+			{Description: "RUN make", Type: "regular", Size: 40},
+			{Description: "FROM alpine", Type: "regular", Size: 60, Shared: true},
+			{Description: "local context", Type: "source.local", Size: 5, InUse: true},
+		},
+	}
+
+	summary := summariseDiskUsage(du)
+
+	assert.EqualValues(t, 2, summary.Images.Count)
+	assert.EqualValues(t, 1, summary.Images.Active)
+	assert.EqualValues(t, 300, summary.Images.Size)
+	assert.EqualValues(t, 150, summary.Images.Reclaimable)
+	assert.EqualValues(t, []DiskUsageItem{
+		{Name: "app:1, app:latest", Size: 200, InUse: true, Detail: "0123456789ab"},
+		{Name: "<none>", Size: 150, Detail: "fedcba987654"},
+	}, summary.Images.Items)
+
+	assert.EqualValues(t, 2, summary.Containers.Count)
+	assert.EqualValues(t, 1, summary.Containers.Active)
+	assert.EqualValues(t, 40, summary.Containers.Size)
+	assert.EqualValues(t, 30, summary.Containers.Reclaimable)
+	assert.EqualValues(t, "old", summary.Containers.Items[0].Name)
+
+	assert.EqualValues(t, 3, summary.Volumes.Count)
+	assert.EqualValues(t, 1, summary.Volumes.Active)
+	assert.EqualValues(t, 520, summary.Volumes.Size)
+	assert.EqualValues(t, 20, summary.Volumes.Reclaimable)
+
+	assert.EqualValues(t, 3, summary.BuildCache.Count)
+	assert.EqualValues(t, 1, summary.BuildCache.Active)
+	assert.EqualValues(t, 45, summary.BuildCache.Size)
+	assert.EqualValues(t, 40, summary.BuildCache.Reclaimable)
+	assert.EqualValues(t, "FROM alpine", summary.BuildCache.Items[0].Name)
+}
@@ -17,6 +17,7 @@ import (
 	ddocker "github.com/docker/cli/cli/context/docker"
 	ctxstore "github.com/docker/cli/cli/context/store"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/imdario/mergo"
 	"github.com/peauc/lazydocker-ng/pkg/commands/ssh"
@@ -38,6 +39,24 @@ type DockerCommand struct {
 	ContainerMutex deadlock.Mutex
 	ServiceMutex   deadlock.Mutex
 
+	// the reports from scanning images for vulnerabilities, by image ID, with a
+	// nil report for images we know haven't been scanned. Guarded by
+	// VulnerabilityReportsMutex
+	vulnerabilityReports      map[string]*VulnerabilityReport
+	VulnerabilityReportsMutex deadlock.Mutex
+
+	// the results of checking registries for newer versions of containers'
+	// images. Guarded by ImageUpdatesMutex
+	imageUpdates      map[string]*ImageUpdate
+	ImageUpdatesMutex deadlock.Mutex
+
+	// the volumes' sizes and reference counts as of volumeUsageFetchedAt. Docker
+	// has to walk every volume to work them out, so we don't ask on every
+	// refresh. Guarded by VolumeUsageMutex
+	cachedVolumeUsage    map[string]*volume.UsageData
+	volumeUsageFetchedAt time.Time
+	VolumeUsageMutex     deadlock.Mutex
+
 	Closers []io.Closer
 }
 
@@ -141,6 +160,9 @@ func NewDockerCommand(log *logrus.Entry, osCommand *OSCommand, tr *i18n.Translat
 		Client:    cli,
 		ErrorChan: errorChan,
 		Closers:   []io.Closer{tunnelResult.Closer},
+
+		vulnerabilityReports: map[string]*VulnerabilityReport{},
+		imageUpdates:         map[string]*ImageUpdate{},
 	}
 
 	dockerCommand.setDockerComposeCommand(config)
@@ -248,9 +270,15 @@ func (c *DockerCommand) GetContainers(existingContainers []*Container) ([]*Conta
 		return nil, err
 	}
 
-	ownContainers := make([]*Container, len(containers))
+	ownContainers := make([]*Container, 0, len(containers))
+
+	for _, ctr := range containers {
+		// our volume helpers are an implementation detail, and we don't want
+		// them counted as users of the volumes they mount
+		if IsVolumeHelper(ctr.Labels) {
+			continue
+		}
 
-	for i, ctr := range containers {
 		var newContainer *Container
 
 		// check if we already have data stored against the container
@@ -289,7 +317,7 @@ func (c *DockerCommand) GetContainers(existingContainers []*Container) ([]*Conta
 		newContainer.ContainerNumber = ctr.Labels["com.docker.compose.container"]
 		newContainer.OneOff = ctr.Labels["com.docker.compose.oneoff"] == "True"
 
-		ownContainers[i] = newContainer
+		ownContainers = append(ownContainers, newContainer)
 	}
 
 	c.SetContainerDetails(ownContainers)
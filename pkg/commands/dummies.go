@@ -17,6 +17,7 @@ func NewDummyOSCommand() *OSCommand {
 
 // NewDummyAppConfig creates a new dummy AppConfig for testing
 func NewDummyAppConfig() *config.AppConfig {
+	userConfig := config.GetDefaultConfig()
 	appConfig := &config.AppConfig{
 		Name:        "lazydocker",
 		Version:     "unversioned",
@@ -24,6 +25,7 @@ func NewDummyAppConfig() *config.AppConfig {
 		BuildDate:   "",
 		Debug:       false,
 		BuildSource: "",
+		UserConfig:  &userConfig,
 	}
 	return appConfig
 }
@@ -48,5 +50,8 @@ func NewDummyDockerCommandWithOSCommand(osCommand *OSCommand) *DockerCommand {
 		OSCommand: osCommand,
 		Tr:        i18n.NewTranslationSet(NewDummyLog(), newAppConfig.UserConfig.Gui.Language),
 		Config:    newAppConfig,
+
+		vulnerabilityReports: map[string]*VulnerabilityReport{},
+		imageUpdates:         map[string]*ImageUpdate{},
 	}
 }
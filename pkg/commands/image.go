@@ -2,9 +2,9 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 	"github.com/fatih/color"
@@ -34,6 +34,38 @@ func (i *Image) Remove(options image.RemoveOptions) error {
 	return nil
 }
 
+// RepoTags returns the image's repository:tag references, leaving out the
+// '<none>:<none>' docker gives untagged images
+func (i *Image) RepoTags() []string {
+	return lo.Filter(i.Image.RepoTags, func(tag string, _ int) bool {
+		return tag != "<none>:<none>"
+	})
+}
+
+// Containers returns those of the given containers which were created from
+// the image
+func (i *Image) Containers(containers []*Container) []*Container {
+	return lo.Filter(containers, func(ctr *Container, _ int) bool {
+		return ctr.Container.ImageID == i.ID
+	})
+}
+
+// AddTag tags the image with the given repository:tag reference
+func (i *Image) AddTag(ref string) error {
+	i.Log.Warn(fmt.Sprintf("tagging image %s as %s", i.ID, ref))
+
+	return i.Client.ImageTag(context.Background(), i.ID, ref)
+}
+
+// RemoveTag removes one of the image's repository:tag references. If it's the
+// image's last reference, docker removes the image itself.
+func (i *Image) RemoveTag(ref string) error {
+	i.Log.Warn(fmt.Sprintf("untagging image %s from %s", i.ID, ref))
+
+	_, err := i.Client.ImageRemove(context.Background(), ref, image.RemoveOptions{})
+	return err
+}
+
 func getHistoryResponseItemDisplayStrings(layer image.HistoryResponseItem) []string {
 	tag := ""
 	if len(layer.Tags) > 0 {
@@ -135,9 +167,3 @@ func (c *DockerCommand) RefreshImages() ([]*Image, error) {
 
 	return ownImages, nil
 }
-
-// PruneImages prunes images
-func (c *DockerCommand) PruneImages() error {
-	_, err := c.Client.ImagesPrune(context.Background(), filters.Args{})
-	return err
-}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/samber/lo"
+)
+
+// the prefixes of the lines docker writes when it's loaded an image
+const (
+	loadedImagePrefix   = "Loaded image: "
+	loadedImageIDPrefix = "Loaded image ID: "
+)
+
+// Export writes the container's filesystem to a tar archive at dstPath,
+// gzipping it if the path ends in .gz or .tgz. onProgress is called with the
+// number of bytes written so far.
+func (c *Container) Export(dstPath string, onProgress func(int64)) error {
+	c.Log.Warn(fmt.Sprintf("exporting container %s to %s", c.Name, dstPath))
+
+	reader, err := c.Client.ContainerExport(context.Background(), c.ID)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return writeArchiveFile(dstPath, reader, onProgress)
+}
+
+// SaveImages writes the given images to a tar archive at dstPath, gzipping it
+// if the path ends in .gz or .tgz. Images are saved with all of their tags so
+// that loading the archive restores them as they are now. onProgress is called
+// with the number of bytes written so far.
+func (c *DockerCommand) SaveImages(images []*Image, dstPath string, onProgress func(int64)) error {
+	refs := imageArchiveRefs(images)
+	c.Log.Warn(fmt.Sprintf("saving images %s to %s", strings.Join(refs, ", "), dstPath))
+
+	reader, err := c.Client.ImageSave(context.Background(), refs)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return writeArchiveFile(dstPath, reader, onProgress)
+}
+
+// imageArchiveRefs returns the references we pass to docker when saving the
+// given images: every tag of each image, or its ID if it's untagged
+func imageArchiveRefs(images []*Image) []string {
+	refs := []string{}
+	for _, image := range images {
+		tags := image.RepoTags()
+		if len(tags) == 0 {
+			tags = []string{image.ID}
+		}
+		refs = append(refs, tags...)
+	}
+
+	return lo.Uniq(refs)
+}
+
+// LoadImages loads the images in the tar archive at srcPath (which may be
+// compressed) into the daemon, returning the references of the loaded images.
+// onProgress is called with the number of bytes read so far and the size of
+// the archive.
+func (c *DockerCommand) LoadImages(srcPath string, onProgress func(read int64, total int64)) ([]string, error) {
+	c.Log.Warn(fmt.Sprintf("loading images from %s", srcPath))
+
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader = file
+	if onProgress != nil {
+		reader = newProgressReader(file, func(read int64) { onProgress(read, info.Size()) })
+	}
+
+	response, err := c.Client.ImageLoad(context.Background(), reader)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return parseImageLoadOutput(response.Body, response.JSON)
+}
+
+// parseImageLoadOutput reads the output of an image load, returning the
+// references of the loaded images, or the error docker reported
+func parseImageLoadOutput(r io.Reader, isJSON bool) ([]string, error) {
+	lines := []string{}
+	if isJSON {
+		err := readJSONMessages(r, func(message jsonmessage.JSONMessage) {
+			lines = append(lines, strings.Split(message.Stream, "\n")...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	refs := []string{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if ref, ok := strings.CutPrefix(line, loadedImagePrefix); ok {
+			refs = append(refs, ref)
+		} else if id, ok := strings.CutPrefix(line, loadedImageIDPrefix); ok {
+			refs = append(refs, id)
+		}
+	}
+
+	return refs, nil
+}
+
+// writeArchiveFile copies an archive to path, gzipping it if the path ends in
+// .gz or .tgz. If something goes wrong, we remove the partially written file.
+func writeArchiveFile(path string, r io.Reader, onProgress func(int64)) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	var w io.Writer = file
+	if isGzipPath(path) {
+		gz := gzip.NewWriter(file)
+		defer func() {
+			if closeErr := gz.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = gz
+	}
+
+	_, err = io.Copy(w, newProgressReader(r, onProgress))
+	return err
+}
+
+// isGzipPath tells us whether an archive at the given path should be gzipped
+func isGzipPath(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz")
+}
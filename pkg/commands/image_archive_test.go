@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseImageLoadOutput is a function.
+func TestParseImageLoadOutput(t *testing.T) {
+	type scenario struct {
+		name     string
+		output   string
+		isJSON   bool
+		expected []string
+		err      string
+	}
+
+	scenarios := []scenario{
+		{
+			"json output",
+			`{"status":"Loading layer","progressDetail":{"current":512,"total":1024},"id":"abc"}
+{"stream":"Loaded image: alpine:latest\n"}
+{"stream":"Loaded image ID: sha256:1234\n"}`,
+			true,
+			[]string{"alpine:latest", "sha256:1234"},
+			"",
+		},
+		{
+			"plain output",
+			"Loaded image: alpine:latest\nLoaded image: alpine:3\n",
+			false,
+			[]string{"alpine:latest", "alpine:3"},
+			"",
+		},
+		{
+			"error",
+			`{"errorDetail":{"message":"invalid tar header"},"error":"invalid tar header"}`,
+			true,
+			nil,
+			"invalid tar header",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			refs, err := parseImageLoadOutput(strings.NewReader(s.output), s.isJSON)
+			if s.err != "" {
+				assert.EqualError(t, err, s.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, refs)
+		})
+	}
+}
+
+// TestImageArchiveRefs is a function.
+func TestImageArchiveRefs(t *testing.T) {
+	images := []*Image{
+		{ID: "sha256:1", Image: image.Summary{RepoTags: []string{"alpine:latest", "alpine:3"}}},
+		{ID: "sha256:2", Image: image.Summary{RepoTags: []string{"<none>:<none>"}}},
+		{ID: "sha256:3"},
+		{ID: "sha256:1", Image: image.Summary{RepoTags: []string{"alpine:latest"}}},
+	}
+
+	assert.EqualValues(t, []string{"alpine:latest", "alpine:3", "sha256:2", "sha256:3"}, imageArchiveRefs(images))
+}
+
+// TestWriteArchiveFile is a function.
+func TestWriteArchiveFile(t *testing.T) {
+	dir := t.TempDir()
+
+	plainPath := filepath.Join(dir, "archive.tar")
+	assert.NoError(t, writeArchiveFile(plainPath, strings.NewReader("content"), nil))
+	content, err := os.ReadFile(plainPath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "content", string(content))
+
+	var written int64
+	gzipPath := filepath.Join(dir, "archive.tar.gz")
+	assert.NoError(t, writeArchiveFile(gzipPath, strings.NewReader("content"), func(n int64) { written = n }))
+	assert.EqualValues(t, 7, written)
+
+	file, err := os.Open(gzipPath)
+	assert.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	assert.NoError(t, err)
+	content, err = io.ReadAll(gz)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "content", string(content))
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// the ID of the messages in which BuildKit sends us the status of a build
+const buildKitTraceID = "moby.buildkit.trace"
+
+// BuildImageOptions are the options for building an image
+type BuildImageOptions struct {
+	// ContextDir is the directory we send to docker as the build context
+	ContextDir string
+	// Dockerfile is the path of the Dockerfile, relative to ContextDir
+	Dockerfile string
+	// Tag is the repository:tag to give the image, if any
+	Tag string
+	// BuildArgs are the build-time variables, as returned by ParseBuildArgs
+	BuildArgs map[string]*string
+	Target    string
+	Platform  string
+}
+
+// BuildEvent is a piece of output from an image build. The legacy builder
+// gives us lines of output in Stream, whereas BuildKit gives us the status of
+// the build's steps.
+type BuildEvent struct {
+	Stream string
+	Status *BuildStatus
+}
+
+// BuildImage builds an image, calling onEvent with the build's output as it
+// goes, and returns the new image's ID. We use BuildKit if the daemon supports
+// it. Cancelling ctx cancels the build.
+func (c *DockerCommand) BuildImage(ctx context.Context, opts BuildImageOptions, onEvent func(BuildEvent)) (string, error) {
+	c.Log.Warn(fmt.Sprintf("building image %s from %s", opts.Tag, opts.ContextDir))
+
+	dockerfilePath := filepath.Join(opts.ContextDir, opts.Dockerfile)
+	if _, err := os.Stat(dockerfilePath); err != nil {
+		return "", err
+	}
+
+	exclude, err := dockerignoreExcluder(opts.ContextDir, opts.Dockerfile)
+	if err != nil {
+		return "", err
+	}
+
+	ping, err := c.Client.Ping(ctx)
+	if err != nil {
+		return "", err
+	}
+	version := build.BuilderV1
+	if ping.BuilderVersion == build.BuilderBuildKit {
+		version = build.BuilderBuildKit
+	}
+
+	tags := []string{}
+	if opts.Tag != "" {
+		tags = append(tags, opts.Tag)
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(createArchive(writer, opts.ContextDir, "", exclude))
+	}()
+	defer reader.Close()
+
+	response, err := c.Client.ImageBuild(ctx, reader, build.ImageBuildOptions{
+		Tags:       tags,
+		Dockerfile: filepath.ToSlash(opts.Dockerfile),
+		BuildArgs:  opts.BuildArgs,
+		Target:     opts.Target,
+		Platform:   opts.Platform,
+		Version:    version,
+		Remove:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	imageID := ""
+	var decodeErr error
+	err = readJSONMessages(response.Body, func(message jsonmessage.JSONMessage) {
+		switch {
+		case message.ID == buildKitTraceID && message.Aux != nil:
+			var data []byte
+			if err := json.Unmarshal(*message.Aux, &data); err != nil {
+				decodeErr = err
+				return
+			}
+			status, err := decodeBuildStatus(data)
+			if err != nil {
+				decodeErr = err
+				return
+			}
+			onEvent(BuildEvent{Status: &status})
+		case message.Aux != nil:
+			// both builders tell us the image's ID this way
+			var result build.Result
+			if err := json.Unmarshal(*message.Aux, &result); err == nil && result.ID != "" {
+				imageID = result.ID
+			}
+		case message.Stream != "":
+			onEvent(BuildEvent{Stream: message.Stream})
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	if decodeErr != nil {
+		return "", decodeErr
+	}
+
+	return imageID, nil
+}
+
+// dockerignoreExcluder returns a function telling us which paths in the build
+// context to leave out according to its .dockerignore file, if it has one. Like
+// the docker CLI, we always send the Dockerfile and the .dockerignore itself.
+func dockerignoreExcluder(contextDir string, dockerfile string) (func(string, os.FileInfo) bool, error) {
+	file, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	patterns, err := ignorefile.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	alwaysSent := []string{filepath.ToSlash(filepath.Clean(dockerfile)), ".dockerignore"}
+
+	return func(rel string, info os.FileInfo) bool {
+		for _, p := range alwaysSent {
+			if rel == p {
+				return false
+			}
+		}
+
+		excluded, err := matcher.MatchesOrParentMatches(rel)
+		if err != nil || !excluded {
+			return false
+		}
+
+		// an exception (e.g. '!dir/keep') can bring back files inside an
+		// excluded directory, so we only skip a directory if nothing we'd
+		// send could be beneath it
+		if info.IsDir() {
+			for _, p := range alwaysSent {
+				if mayMatchBelow(p, rel) {
+					return false
+				}
+			}
+			for _, pattern := range matcher.Patterns() {
+				if pattern.Exclusion() && mayMatchBelow(pattern.String(), rel) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}, nil
+}
+
+// mayMatchBelow tells us whether a .dockerignore pattern could match a path
+// beneath the given directory. We only look at the pattern up to its first
+// wildcard, so we err on the side of it matching.
+func mayMatchBelow(pattern string, dir string) bool {
+	dirSlash := dir + "/"
+	if i := strings.IndexAny(pattern, "*?[\\"); i != -1 {
+		literal := pattern[:i]
+		return strings.HasPrefix(dirSlash, literal) || strings.HasPrefix(literal, dirSlash)
+	}
+
+	return strings.HasPrefix(pattern+"/", dirSlash)
+}
+
+// ParseBuildArgs parses a ';'-separated list of build-time variables e.g.
+// `VERSION=1.2; DEBUG`. Like with `docker build --build-arg`, a variable without
+// a value takes its value from our environment, if it's set there.
+func ParseBuildArgs(str string) (map[string]*string, error) {
+	args := map[string]*string{}
+	for _, arg := range strings.Split(str, ";") {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid build arg '%s'. Expected NAME=value", arg)
+		}
+
+		if hasValue {
+			args[name] = &value
+		} else if envValue, ok := os.LookupEnv(name); ok {
+			args[name] = &envValue
+		} else {
+			args[name] = nil
+		}
+	}
+
+	return args, nil
+}
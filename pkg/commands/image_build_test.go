@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseBuildArgs is a function.
+func TestParseBuildArgs(t *testing.T) {
+	t.Setenv("LAZYDOCKER_TEST_ARG", "from-env")
+
+	args, err := ParseBuildArgs("VERSION=1.2; EMPTY=; LAZYDOCKER_TEST_ARG; LAZYDOCKER_UNSET_ARG;")
+	assert.NoError(t, err)
+	assert.Len(t, args, 4)
+	assert.EqualValues(t, "1.2", *args["VERSION"])
+	assert.EqualValues(t, "", *args["EMPTY"])
+	assert.EqualValues(t, "from-env", *args["LAZYDOCKER_TEST_ARG"])
+	assert.Nil(t, args["LAZYDOCKER_UNSET_ARG"])
+
+	_, err = ParseBuildArgs("=value")
+	assert.Error(t, err)
+}
+
+// TestDockerignoreExcluder is a function.
+func TestDockerignoreExcluder(t *testing.T) {
+	contextDir := t.TempDir()
+
+	files := map[string]string{
+		".dockerignore":           "*.log\nnode_modules\nbuild\n!build/keep\ndocker/Dockerfile\n",
+		"docker/Dockerfile":       "FROM alpine",
+		"main.go":                 "package main",
+		"debug.log":               "",
+		"node_modules/a/index.js": "",
+		"build/out":               "",
+		"build/keep":              "",
+	}
+	for name, content := range files {
+		p := filepath.Join(contextDir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	exclude, err := dockerignoreExcluder(contextDir, "docker/Dockerfile")
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, createArchive(buf, contextDir, "", exclude))
+	entries, err := readArchiveEntries(buf)
+	assert.NoError(t, err)
+
+	paths := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			paths = append(paths, entry.Path)
+		}
+	}
+	assert.ElementsMatch(t, []string{".dockerignore", "docker/Dockerfile", "main.go", "build/keep"}, paths)
+
+	// an exception only stops us skipping the directories it could match in
+	dirInfo, err := os.Stat(contextDir)
+	assert.NoError(t, err)
+	assert.True(t, exclude("node_modules", dirInfo))
+	assert.False(t, exclude("build", dirInfo))
+	assert.True(t, mayMatchBelow("**/keep", "node_modules"))
+	assert.True(t, mayMatchBelow("build/*/keep", "build/a"))
+	assert.False(t, mayMatchBelow("build/*/keep", "dist"))
+
+	exclude, err = dockerignoreExcluder(t.TempDir(), "Dockerfile")
+	assert.NoError(t, err)
+	assert.Nil(t, exclude)
+}
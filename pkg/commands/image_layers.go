@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// the prefixes docker uses in layers for files that delete files in lower layers
+const (
+	whiteoutPrefix = ".wh."
+	// an opaque whiteout deletes everything that lower layers put in its directory
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+// the most we read of a JSON file (e.g. the image config) in an image archive
+const maxArchiveJSONSize = 16 * 1024 * 1024
+
+// ImageLayerAnalysis tells us what each of an image's layers does to its
+// filesystem, and how much space is wasted on files which later layers
+// overwrite or delete.
+type ImageLayerAnalysis struct {
+	Layers []ImageLayer
+	// TotalSize is the size of all the files in all the layers
+	TotalSize int64
+	// WastedSize is the size of the files which are overwritten or deleted by a
+	// later layer, and so take up space without being in the final image
+	WastedSize  int64
+	WastedFiles []WastedFile
+}
+
+// ImageLayer is a step in an image's history, along with the changes it made
+// to the filesystem. Some steps (e.g. ENV instructions) don't create a layer,
+// in which case Empty is true.
+type ImageLayer struct {
+	CreatedBy string
+	Empty     bool
+	Size      int64
+	Changes   []LayerChange
+}
+
+// LayerChange is a file which a layer adds, changes or removes
+type LayerChange struct {
+	Path string
+	Size int64
+	Kind container.ChangeType
+}
+
+// WastedFile is a path which is written to (or deleted) by more than one layer
+type WastedFile struct {
+	Path string
+	// Count is the number of layers which touch the path
+	Count int
+	// Size is the space taken up by the versions of the file that don't make it
+	// into the final image
+	Size int64
+}
+
+// Efficiency returns the proportion of the space taken up by the image's
+// layers which is actually used by its final filesystem, from 0 to 1
+func (a *ImageLayerAnalysis) Efficiency() float64 {
+	if a.TotalSize == 0 {
+		return 1
+	}
+
+	return 1 - float64(a.WastedSize)/float64(a.TotalSize)
+}
+
+// AnalyseLayers saves the image and reads its layers from the archive to work
+// out what each layer does to the image's filesystem. This streams the whole
+// image from docker, so it can take a while for large images. Cancelling ctx
+// cancels the analysis.
+func (i *Image) AnalyseLayers(ctx context.Context) (*ImageLayerAnalysis, error) {
+	reader, err := i.Client.ImageSave(ctx, []string{i.ID})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return analyseImageArchive(reader)
+}
+
+type archiveLayerEntry struct {
+	path  string
+	size  int64
+	isDir bool
+}
+
+type archiveManifest struct {
+	Config string
+	Layers []string
+}
+
+type archiveImageConfig struct {
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// analyseImageArchive reads an archive created by `docker save` for a single
+// image. Both the legacy format and the OCI layout (used since docker 25) have
+// a manifest.json listing the image's config and layers, but we don't know
+// where in the archive it'll be, so we read every layer-looking file as we come
+// across it and then piece things together at the end.
+func analyseImageArchive(r io.Reader) (*ImageLayerAnalysis, error) {
+	jsonFiles := map[string][]byte{}
+	layers := map[string][]archiveLayerEntry{}
+	symlinks := map[string]string{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(hdr.Name)
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			// the legacy format links each layer.tar to its blob in the OCI layout
+			symlinks[name] = path.Join(path.Dir(name), hdr.Linkname)
+			continue
+		case tar.TypeReg:
+		default:
+			continue
+		}
+
+		br := bufio.NewReader(tr)
+		magic, _ := br.Peek(2)
+		switch {
+		case len(magic) > 0 && (magic[0] == '{' || magic[0] == '['):
+			content, err := io.ReadAll(io.LimitReader(br, maxArchiveJSONSize))
+			if err != nil {
+				return nil, err
+			}
+			jsonFiles[name] = content
+		case len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b:
+			gz, err := gzip.NewReader(br)
+			if err != nil {
+				continue
+			}
+			if entries, err := readLayerEntries(gz); err == nil {
+				layers[name] = entries
+			}
+		default:
+			if entries, err := readLayerEntries(br); err == nil {
+				layers[name] = entries
+			}
+		}
+	}
+
+	manifests := []archiveManifest{}
+	if err := json.Unmarshal(jsonFiles["manifest.json"], &manifests); err != nil {
+		return nil, fmt.Errorf("reading image manifest: %w", err)
+	}
+	if len(manifests) == 0 {
+		return nil, errors.New("the image archive has no manifest")
+	}
+	manifest := manifests[0]
+
+	config := archiveImageConfig{}
+	if content, ok := jsonFiles[resolveArchiveSymlink(symlinks, manifest.Config)]; ok {
+		if err := json.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("reading image config: %w", err)
+		}
+	}
+
+	layerEntries := make([][]archiveLayerEntry, len(manifest.Layers))
+	for idx, layerPath := range manifest.Layers {
+		entries, ok := layers[resolveArchiveSymlink(symlinks, layerPath)]
+		if !ok {
+			return nil, fmt.Errorf("layer %s is missing from the image archive", layerPath)
+		}
+		layerEntries[idx] = entries
+	}
+
+	return analyseLayers(config, layerEntries), nil
+}
+
+func resolveArchiveSymlink(symlinks map[string]string, name string) string {
+	name = path.Clean(name)
+	// a symlink could point to another symlink, but there's no good reason for
+	// a chain of them to be long
+	for i := 0; i < 10; i++ {
+		target, ok := symlinks[name]
+		if !ok {
+			break
+		}
+		name = target
+	}
+	return name
+}
+
+// readLayerEntries lists the entries of a layer's tar archive
+func readLayerEntries(r io.Reader) ([]archiveLayerEntry, error) {
+	entries := []archiveLayerEntry{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, archiveLayerEntry{
+			path:  path.Join("/", hdr.Name),
+			size:  hdr.Size,
+			isDir: hdr.Typeflag == tar.TypeDir,
+		})
+	}
+}
+
+// analyseLayers replays each layer's entries on top of the layers below it,
+// working out what it adds, changes and removes, and which files end up
+// overwritten or deleted
+func analyseLayers(config archiveImageConfig, layerEntries [][]archiveLayerEntry) *ImageLayerAnalysis {
+	analysis := &ImageLayerAnalysis{}
+
+	// the files (not directories) currently in the image, with their sizes
+	files := map[string]int64{}
+	// how many layers touch each path
+	counts := map[string]int{}
+	wasted := map[string]int64{}
+
+	waste := func(p string, size int64) {
+		wasted[p] += size
+		analysis.WastedSize += size
+	}
+
+	// the paths directly under each directory, so that removing a directory
+	// doesn't mean going through every file in the image
+	children := map[string]map[string]bool{}
+	index := func(p string) {
+		for p != "." && p != "/" {
+			parent := path.Dir(p)
+			if children[parent] == nil {
+				children[parent] = map[string]bool{}
+			}
+			if children[parent][p] {
+				// so are its ancestors
+				return
+			}
+			children[parent][p] = true
+			p = parent
+		}
+	}
+
+	// removes p and everything under it if it's a directory, calling onFile
+	// with each file removed
+	var removeTree func(p string, onFile func(file string, size int64))
+	removeTree = func(p string, onFile func(file string, size int64)) {
+		if size, ok := files[p]; ok {
+			waste(p, size)
+			counts[p]++
+			delete(files, p)
+			onFile(p, size)
+		}
+		for child := range children[p] {
+			removeTree(child, onFile)
+		}
+		delete(children, p)
+	}
+
+	// removes p (and everything under it if it's a directory), returning the
+	// size of what was removed
+	remove := func(p string) int64 {
+		var removed int64
+		removeTree(p, func(_ string, size int64) { removed += size })
+		delete(children[path.Dir(p)], p)
+		return removed
+	}
+
+	applyLayer := func(entries []archiveLayerEntry) ImageLayer {
+		layer := ImageLayer{}
+
+		// whiteouts only hide what lower layers put there, but a layer can list
+		// its own files before the whiteouts in the same directory. So we apply
+		// all of its whiteouts before adding any of its files.
+		whiteouts, others := []archiveLayerEntry{}, []archiveLayerEntry{}
+		for _, entry := range entries {
+			if strings.HasPrefix(path.Base(entry.path), whiteoutPrefix) {
+				whiteouts = append(whiteouts, entry)
+			} else {
+				others = append(others, entry)
+			}
+		}
+
+		for _, entry := range append(whiteouts, others...) {
+			dir, base := path.Split(entry.path)
+			dir = path.Clean(dir)
+
+			switch {
+			case base == opaqueWhiteout:
+				for child := range children[dir] {
+					removeTree(child, func(file string, size int64) {
+						layer.Changes = append(layer.Changes, LayerChange{Path: file, Size: size, Kind: container.ChangeDelete})
+					})
+				}
+				delete(children, dir)
+			case strings.HasPrefix(base, whiteoutPrefix):
+				target := path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix))
+				size := remove(target)
+				layer.Changes = append(layer.Changes, LayerChange{Path: target, Size: size, Kind: container.ChangeDelete})
+			case entry.isDir:
+				continue
+			default:
+				kind := container.ChangeAdd
+				if size, ok := files[entry.path]; ok {
+					kind = container.ChangeModify
+					waste(entry.path, size)
+				}
+				files[entry.path] = entry.size
+				index(entry.path)
+				counts[entry.path]++
+				layer.Size += entry.size
+				analysis.TotalSize += entry.size
+				layer.Changes = append(layer.Changes, LayerChange{Path: entry.path, Size: entry.size, Kind: kind})
+			}
+		}
+
+		sort.Slice(layer.Changes, func(i, j int) bool { return layer.Changes[i].Path < layer.Changes[j].Path })
+		return layer
+	}
+
+	layerIdx := 0
+	for _, history := range config.History {
+		if history.EmptyLayer || layerIdx >= len(layerEntries) {
+			analysis.Layers = append(analysis.Layers, ImageLayer{CreatedBy: history.CreatedBy, Empty: true})
+			continue
+		}
+
+		layer := applyLayer(layerEntries[layerIdx])
+		layer.CreatedBy = history.CreatedBy
+		analysis.Layers = append(analysis.Layers, layer)
+		layerIdx++
+	}
+	// in case the image has no history, or less than it has layers
+	for ; layerIdx < len(layerEntries); layerIdx++ {
+		analysis.Layers = append(analysis.Layers, applyLayer(layerEntries[layerIdx]))
+	}
+
+	for p, size := range wasted {
+		analysis.WastedFiles = append(analysis.WastedFiles, WastedFile{Path: p, Count: counts[p], Size: size})
+	}
+	sort.Slice(analysis.WastedFiles, func(i, j int) bool {
+		a, b := analysis.WastedFiles[i], analysis.WastedFiles[j]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return a.Path < b.Path
+	})
+
+	return analysis
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTarEntry struct {
+	name     string
+	content  []byte
+	typeflag byte
+	linkname string
+}
+
+func buildTestTar(t *testing.T, entries []testTarEntry) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, entry := range entries {
+		typeflag := entry.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     entry.name,
+			Typeflag: typeflag,
+			Linkname: entry.linkname,
+			Size:     int64(len(entry.content)),
+			Mode:     0o644,
+		}))
+		_, err := tw.Write(entry.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+// TestAnalyseImageArchive is a function.
+func TestAnalyseImageArchive(t *testing.T) {
+	base := buildTestTar(t, []testTarEntry{
+		{name: "etc/", typeflag: tar.TypeDir},
+		{name: "etc/hosts", content: []byte("0123456789")},
+		{name: "var/cache/", typeflag: tar.TypeDir},
+		{name: "var/cache/apt.bin", content: bytes.Repeat([]byte("x"), 100)},
+		{name: "var/cache/index", content: bytes.Repeat([]byte("y"), 50)},
+	})
+	install := buildTestTar(t, []testTarEntry{
+		{name: "etc/", typeflag: tar.TypeDir},
+		{name: "etc/hosts", content: []byte("01234")},
+		{name: "usr/bin/app", content: bytes.Repeat([]byte("z"), 20)},
+	})
+	cleanup := buildTestTar(t, []testTarEntry{
+		{name: "var/cache/.wh..wh..opq"},
+		{name: "usr/bin/.wh.app"},
+	})
+
+	config := `{"history": [
+		{"created_by": "ADD rootfs.tar /"},
+		{"created_by": "ENV FOO=bar", "empty_layer": true},
+		{"created_by": "RUN install"},
+		{"created_by": "RUN cleanup"}
+	]}`
+
+	// in the legacy format the manifest comes last, and layers link to blobs
+	archive := buildTestTar(t, []testTarEntry{
+		{name: "blobs/sha256/base", content: base},
+		{name: "blobs/sha256/install", content: install},
+		{name: "blobs/sha256/cleanup", content: cleanup},
+		{name: "blobs/sha256/config", content: []byte(config)},
+		{name: "cleanup/layer.tar", typeflag: tar.TypeSymlink, linkname: "../blobs/sha256/cleanup"},
+		{name: "manifest.json", content: []byte(`[{"Config": "blobs/sha256/config", "Layers": ["blobs/sha256/base", "blobs/sha256/install", "cleanup/layer.tar"]}]`)},
+	})
+
+	analysis, err := analyseImageArchive(bytes.NewReader(archive))
+	assert.NoError(t, err)
+
+	assert.Len(t, analysis.Layers, 4)
+
+	assert.EqualValues(t, "ADD rootfs.tar /", analysis.Layers[0].CreatedBy)
+	assert.EqualValues(t, 160, analysis.Layers[0].Size)
+	assert.Len(t, analysis.Layers[0].Changes, 3)
+
+	assert.True(t, analysis.Layers[1].Empty)
+
+	assert.EqualValues(t, []LayerChange{
+		{Path: "/etc/hosts", Size: 5, Kind: container.ChangeModify},
+		{Path: "/usr/bin/app", Size: 20, Kind: container.ChangeAdd},
+	}, analysis.Layers[2].Changes)
+
+	assert.EqualValues(t, []LayerChange{
+		{Path: "/usr/bin/app", Size: 20, Kind: container.ChangeDelete},
+		{Path: "/var/cache/apt.bin", Size: 100, Kind: container.ChangeDelete},
+		{Path: "/var/cache/index", Size: 50, Kind: container.ChangeDelete},
+	}, analysis.Layers[3].Changes)
+
+	assert.EqualValues(t, 185, analysis.TotalSize)
+	assert.EqualValues(t, 180, analysis.WastedSize)
+	assert.InDelta(t, 5.0/185.0, analysis.Efficiency(), 0.0001)
+
+	assert.EqualValues(t, []WastedFile{
+		{Path: "/var/cache/apt.bin", Count: 2, Size: 100},
+		{Path: "/var/cache/index", Count: 2, Size: 50},
+		{Path: "/usr/bin/app", Count: 2, Size: 20},
+		{Path: "/etc/hosts", Count: 2, Size: 10},
+	}, analysis.WastedFiles)
+}
+
+// TestAnalyseLayersOpaqueWhiteout is a function.
+func TestAnalyseLayersOpaqueWhiteout(t *testing.T) {
+	base := []archiveLayerEntry{
+		{path: "/app", isDir: true},
+		{path: "/app/old", size: 10},
+	}
+	// the layer lists its own file before the opaque whiteout, which should
+	// only hide what the base layer put in the directory
+	replace := []archiveLayerEntry{
+		{path: "/app", isDir: true},
+		{path: "/app/new", size: 20},
+		{path: "/app/.wh..wh..opq"},
+	}
+
+	analysis := analyseLayers(archiveImageConfig{}, [][]archiveLayerEntry{base, replace})
+
+	assert.Len(t, analysis.Layers, 2)
+	assert.EqualValues(t, []LayerChange{
+		{Path: "/app/new", Size: 20, Kind: container.ChangeAdd},
+		{Path: "/app/old", Size: 10, Kind: container.ChangeDelete},
+	}, analysis.Layers[1].Changes)
+	assert.EqualValues(t, 30, analysis.TotalSize)
+	assert.EqualValues(t, 10, analysis.WastedSize)
+}
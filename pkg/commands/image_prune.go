@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/samber/lo"
+)
+
+// ImagePruneOptions narrows down which images PruneImages removes. The zero
+// value prunes dangling images, like `docker image prune`.
+type ImagePruneOptions struct {
+	// All prunes every image that no container uses, rather than only dangling
+	// (i.e. untagged) ones
+	All bool
+	// Until, if not empty, only prunes images created before the given time:
+	// either a duration like '24h', relative to now, or a timestamp
+	Until string
+	// Labels only prunes images with all of the given labels, each in the form
+	// 'key' or 'key=value'. Prefixing a label with '!' instead only prunes images
+	// without it.
+	Labels []string
+}
+
+// the formats docker accepts for the 'until' filter, aside from durations and
+// unix timestamps
+var untilTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseUntil works out the time an 'until' filter refers to, relative to now
+func ParseUntil(until string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(until); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	for _, format := range untilTimeFormats {
+		if t, err := time.ParseInLocation(format, until, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(until, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is neither a duration (e.g. 24h) nor a timestamp (e.g. 2006-01-02)", until)
+}
+
+func (o ImagePruneOptions) filterArgs() filters.Args {
+	args := filters.NewArgs()
+	if o.All {
+		args.Add("dangling", "false")
+	}
+	if o.Until != "" {
+		args.Add("until", o.Until)
+	}
+	addLabelFilters(args, o.Labels)
+	return args
+}
+
+// IsDangling tells us if the image has no tags, meaning it's been superseded
+// by a newer image with the same tag, or it's from a build that wasn't tagged
+func (i *Image) IsDangling() bool {
+	return len(i.RepoTags()) == 0
+}
+
+// ImagePrunePreview tells us what PruneImages would remove
+type ImagePrunePreview struct {
+	Images []*Image
+	// TotalSize is the combined size of the images, counting the layers they
+	// share with each other (or other images) once per image
+	TotalSize int64
+	// UniqueSize is the size of the images' layers which no other image uses.
+	// Pruning reclaims at least this much: layers the images only share with
+	// each other are reclaimed too.
+	UniqueSize int64
+}
+
+// PreviewImagePrune works out which of the given images PruneImages would
+// remove with the given options, given the containers that exist. It follows
+// the rules docker uses when pruning.
+func (c *DockerCommand) PreviewImagePrune(images []*Image, containers []*Container, options ImagePruneOptions) (*ImagePrunePreview, error) {
+	candidates, err := imagesToPrune(images, containers, options, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImagePrunePreview{Images: candidates}
+	if len(candidates) == 0 {
+		return preview, nil
+	}
+
+	// docker only works out how much of each image is shared with other images
+	// if we ask for it, which is slow, so we don't do it when refreshing images
+	summaries, err := c.Client.ImageList(context.Background(), image.ListOptions{SharedSize: true})
+	if err != nil {
+		return nil, err
+	}
+	sharedSizes := lo.SliceToMap(summaries, func(summary image.Summary) (string, int64) {
+		return summary.ID, summary.SharedSize
+	})
+
+	for _, img := range candidates {
+		preview.TotalSize += img.Image.Size
+		// the shared size is -1 if docker couldn't work it out
+		if shared, ok := sharedSizes[img.ID]; ok && shared >= 0 {
+			preview.UniqueSize += img.Image.Size - shared
+		}
+	}
+
+	return preview, nil
+}
+
+func imagesToPrune(images []*Image, containers []*Container, options ImagePruneOptions, now time.Time) ([]*Image, error) {
+	var until time.Time
+	if options.Until != "" {
+		var err error
+		until, err = ParseUntil(options.Until, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inUse := lo.SliceToMap(containers, func(ctr *Container) (string, bool) {
+		return ctr.Container.ImageID, true
+	})
+
+	candidates := lo.Filter(images, func(img *Image, _ int) bool {
+		if inUse[img.ID] {
+			return false
+		}
+
+		if !options.All && !img.IsDangling() {
+			return false
+		}
+
+		if !until.IsZero() && !time.Unix(img.Image.Created, 0).Before(until) {
+			return false
+		}
+
+		return matchesLabelFilters(img.Image.Labels, options.Labels)
+	})
+
+	// docker won't remove an image that's the parent of another, e.g. one of
+	// the intermediate images of a classic build, unless it's removing the
+	// child too. Keeping one image can keep its parent, so we go until
+	// there's nothing more to keep.
+	pruned := lo.SliceToMap(candidates, func(img *Image) (string, bool) { return img.ID, true })
+	for {
+		kept := false
+		for _, img := range images {
+			parentID := img.Image.ParentID
+			if parentID != "" && !pruned[img.ID] && pruned[parentID] {
+				delete(pruned, parentID)
+				kept = true
+			}
+		}
+		if !kept {
+			break
+		}
+	}
+
+	return lo.Filter(candidates, func(img *Image, _ int) bool { return pruned[img.ID] }), nil
+}
+
+// PruneImages prunes the images matching the given options, returning what
+// was removed
+func (c *DockerCommand) PruneImages(options ImagePruneOptions) (image.PruneReport, error) {
+	return c.Client.ImagesPrune(context.Background(), options.filterArgs())
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseUntil is a function.
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+
+	type scenario struct {
+		until    string
+		expected time.Time
+		hasError bool
+	}
+
+	scenarios := []scenario{
+		{"24h", now.Add(-24 * time.Hour), false},
+		{"90m", now.Add(-90 * time.Minute), false},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local), false},
+		{"2024-05-01T10:30:00", time.Date(2024, 5, 1, 10, 30, 0, 0, time.Local), false},
+		{"1700000000", time.Unix(1700000000, 0), false},
+		{"yesterday", time.Time{}, true},
+	}
+
+	for _, s := range scenarios {
+		actual, err := ParseUntil(s.until, now)
+		if s.hasError {
+			assert.Error(t, err, s.until)
+			continue
+		}
+		assert.NoError(t, err, s.until)
+		assert.True(t, s.expected.Equal(actual), s.until)
+	}
+}
+
+// TestImagesToPrune is a function.
+func TestImagesToPrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour).Unix()
+	recent := now.Add(-time.Hour).Unix()
+
+	newImage := func(id string, tags []string, created int64, labels map[string]string) *Image {
+		return &Image{ID: id, Image: image.Summary{ID: id, RepoTags: tags, Created: created, Labels: labels}}
+	}
+
+	danglingOld := newImage("dangling-old", nil, old, nil)
+	danglingRecent := newImage("dangling-recent", []string{"<none>:<none>"}, recent, map[string]string{"env": "dev"})
+	taggedOld := newImage("tagged-old", []string{"app:1"}, old, map[string]string{"env": "prod", "keep": ""})
+	taggedInUse := newImage("tagged-in-use", []string{"app:2"}, old, nil)
+	danglingInUse := newImage("dangling-in-use", nil, old, nil)
+	// intermediate images of a classic build: docker keeps the parent of an
+	// image it keeps, and so on up the chain, but removes a parent along with
+	// its last child
+	buildBase := newImage("build-base", nil, old, nil)
+	buildStep := newImage("build-step", nil, old, nil)
+	buildStep.Image.ParentID = buildBase.ID
+	taggedBuild := newImage("tagged-build", []string{"built:1"}, recent, nil)
+	taggedBuild.Image.ParentID = buildStep.ID
+	danglingChild := newImage("dangling-child", nil, old, nil)
+	danglingChild.Image.ParentID = danglingOld.ID
+
+	images := []*Image{danglingOld, danglingRecent, taggedOld, taggedInUse, danglingInUse, buildBase, buildStep, taggedBuild, danglingChild}
+	containers := []*Container{
+		{Container: container.Summary{ImageID: "tagged-in-use"}},
+		{Container: container.Summary{ImageID: "dangling-in-use"}},
+	}
+
+	type scenario struct {
+		name     string
+		options  ImagePruneOptions
+		expected []*Image
+	}
+
+	scenarios := []scenario{
+		{"dangling", ImagePruneOptions{}, []*Image{danglingOld, danglingRecent, danglingChild}},
+		{"all", ImagePruneOptions{All: true}, []*Image{danglingOld, danglingRecent, taggedOld, buildBase, buildStep, taggedBuild, danglingChild}},
+		{"until", ImagePruneOptions{All: true, Until: "24h"}, []*Image{danglingOld, taggedOld, danglingChild}},
+		{"label", ImagePruneOptions{All: true, Labels: []string{"env"}}, []*Image{danglingRecent, taggedOld}},
+		{"label value", ImagePruneOptions{All: true, Labels: []string{"env=dev"}}, []*Image{danglingRecent}},
+		{"negated label", ImagePruneOptions{All: true, Labels: []string{"!keep"}}, []*Image{danglingOld, danglingRecent, buildBase, buildStep, taggedBuild, danglingChild}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := imagesToPrune(images, containers, s.options, now)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+}
+
+// TestImagePruneFilterArgs is a function.
+func TestImagePruneFilterArgs(t *testing.T) {
+	args := ImagePruneOptions{All: true, Until: "24h", Labels: []string{"env=dev", "!keep"}}.filterArgs()
+
+	assert.EqualValues(t, []string{"false"}, args.Get("dangling"))
+	assert.EqualValues(t, []string{"24h"}, args.Get("until"))
+	assert.EqualValues(t, []string{"env=dev"}, args.Get("label"))
+	assert.EqualValues(t, []string{"keep"}, args.Get("label!"))
+
+	assert.Equal(t, 0, ImagePruneOptions{}.filterArgs().Len())
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/distribution/reference"
+	cliconfig "github.com/docker/cli/cli/config"
+	clitypes "github.com/docker/cli/cli/config/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// the key docker hub credentials are stored under in the docker config file
+const dockerHubAuthServer = "https://index.docker.io/v1/"
+
+// NormaliseImageReference validates an image reference and adds the 'latest'
+// tag if it doesn't have a tag or digest, e.g. 'alpine' becomes 'alpine:latest'
+func NormaliseImageReference(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return reference.FamiliarString(reference.TagNameOnly(named)), nil
+}
+
+// ImageRepository returns the repository of an image reference, without its
+// tag or digest, e.g. 'ghcr.io/owner/app:v1' gives us 'ghcr.io/owner/app'
+func ImageRepository(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return reference.FamiliarName(named), nil
+}
+
+// PullImage pulls the image with the given reference, using any credentials
+// for its registry in the docker config file. onMessage is called with each
+// progress message docker sends us. Cancelling ctx cancels the pull.
+func (c *DockerCommand) PullImage(ctx context.Context, ref string, onMessage func(jsonmessage.JSONMessage)) error {
+	c.Log.Warn(fmt.Sprintf("pulling image %s", ref))
+
+	auth, err := registryAuth(ref)
+	if err != nil {
+		return err
+	}
+
+	reader, err := c.Client.ImagePull(ctx, ref, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readJSONMessages(reader, onMessage)
+}
+
+// PushImage pushes the image with the given repository:tag reference, using
+// any credentials for its registry in the docker config file. onMessage is
+// called with each progress message docker sends us. Cancelling ctx cancels
+// the push.
+func (c *DockerCommand) PushImage(ctx context.Context, ref string, onMessage func(jsonmessage.JSONMessage)) error {
+	c.Log.Warn(fmt.Sprintf("pushing image %s", ref))
+
+	auth, err := registryAuth(ref)
+	if err != nil {
+		return err
+	}
+
+	reader, err := c.Client.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readJSONMessages(reader, onMessage)
+}
+
+// registryAuth returns the credentials stored in the docker config file for
+// the registry hosting the given image, encoded the way the docker API expects
+// them. We return an empty string if there are no credentials, in which case
+// docker accesses the registry anonymously.
+func registryAuth(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+
+	server := reference.Domain(named)
+	if server == "docker.io" {
+		server = dockerHubAuthServer
+	}
+
+	configFile, err := cliconfig.Load(cliconfig.Dir())
+	if err != nil {
+		return "", err
+	}
+
+	authConfig, err := configFile.GetAuthConfig(server)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeRegistryAuth(authConfig)
+}
+
+func encodeRegistryAuth(authConfig clitypes.AuthConfig) (string, error) {
+	// the credentials store gives us back the server address even when it has
+	// no credentials for it
+	if authConfig == (clitypes.AuthConfig{ServerAddress: authConfig.ServerAddress}) {
+		return "", nil
+	}
+
+	return registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      authConfig.Username,
+		Password:      authConfig.Password,
+		Auth:          authConfig.Auth,
+		ServerAddress: authConfig.ServerAddress,
+		IdentityToken: authConfig.IdentityToken,
+		RegistryToken: authConfig.RegistryToken,
+	})
+}
+
+// readJSONMessages decodes a stream of docker progress messages (as returned
+// when pulling, pushing or loading images), calling onMessage with each one.
+// We return the first error docker reports in the stream.
+func readJSONMessages(r io.Reader, onMessage func(jsonmessage.JSONMessage)) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var message jsonmessage.JSONMessage
+		if err := decoder.Decode(&message); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if message.Error != nil {
+			return message.Error
+		}
+
+		if onMessage != nil {
+			onMessage(message)
+		}
+	}
+}
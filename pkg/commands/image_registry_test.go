@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"testing"
+
+	clitypes "github.com/docker/cli/cli/config/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormaliseImageReference is a function.
+func TestNormaliseImageReference(t *testing.T) {
+	type scenario struct {
+		ref      string
+		expected string
+		err      bool
+	}
+
+	scenarios := []scenario{
+		{"alpine", "alpine:latest", false},
+		{"alpine:3.20", "alpine:3.20", false},
+		{"docker.io/library/alpine", "alpine:latest", false},
+		{"ghcr.io/owner/app:v1", "ghcr.io/owner/app:v1", false},
+		{"Alpine", "", true},
+	}
+
+	for _, s := range scenarios {
+		ref, err := NormaliseImageReference(s.ref)
+		if s.err {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.EqualValues(t, s.expected, ref)
+	}
+}
+
+// TestEncodeRegistryAuth is a function.
+func TestEncodeRegistryAuth(t *testing.T) {
+	auth, err := encodeRegistryAuth(clitypes.AuthConfig{ServerAddress: "ghcr.io"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "", auth)
+
+	auth, err = encodeRegistryAuth(clitypes.AuthConfig{ServerAddress: "ghcr.io", Username: "me", Password: "secret"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, auth)
+}
+
+// TestImageRepository is a function.
+func TestImageRepository(t *testing.T) {
+	repository, err := ImageRepository("ghcr.io/owner/app:v1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "ghcr.io/owner/app", repository)
+
+	repository, err = ImageRepository("docker.io/library/alpine:3.20")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "alpine", repository)
+}
@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// The severities a vulnerability can have, most severe first. Scanners use
+// their own capitalisation, so we upper-case whatever they give us.
+const (
+	SeverityCritical   = "CRITICAL"
+	SeverityHigh       = "HIGH"
+	SeverityMedium     = "MEDIUM"
+	SeverityLow        = "LOW"
+	SeverityNegligible = "NEGLIGIBLE"
+	SeverityUnknown    = "UNKNOWN"
+)
+
+// Severities lists the severities from most to least severe
+var Severities = []string{
+	SeverityCritical,
+	SeverityHigh,
+	SeverityMedium,
+	SeverityLow,
+	SeverityNegligible,
+	SeverityUnknown,
+}
+
+// SeverityRank returns how severe a severity is, with zero being the most
+// severe. Severities we don't recognise rank as unknown.
+func SeverityRank(severity string) int {
+	rank := lo.IndexOf(Severities, severity)
+	if rank == -1 {
+		return len(Severities) - 1
+	}
+	return rank
+}
+
+// Vulnerability is a CVE (or similar advisory) affecting one of an image's
+// packages
+type Vulnerability struct {
+	ID               string
+	Severity         string
+	Package          string
+	InstalledVersion string
+	// FixedVersion is the version of the package which fixes the
+	// vulnerability, empty if there's no fix yet
+	FixedVersion string
+	Title        string
+}
+
+// VulnerabilityReport is the result of scanning an image for vulnerabilities
+type VulnerabilityReport struct {
+	ImageID         string
+	ScannedAt       time.Time
+	Vulnerabilities []*Vulnerability
+}
+
+// Counts returns how many vulnerabilities the report has of each severity
+func (r *VulnerabilityReport) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, vuln := range r.Vulnerabilities {
+		counts[vuln.Severity]++
+	}
+	return counts
+}
+
+// VulnerabilitySort determines the order we list a report's vulnerabilities in
+type VulnerabilitySort int
+
+const (
+	// most severe first
+	SortBySeverity VulnerabilitySort = iota
+	// alphabetically by package
+	SortByPackage
+	// fixable vulnerabilities first, ordered by the version that fixes them
+	SortByFixedVersion
+
+	// the number of orders above, for cycling through them
+	VulnerabilitySortCount
+)
+
+// SortVulnerabilities sorts the given vulnerabilities in place. Ties are
+// broken by severity, then package, then ID, so the order is always the same.
+func SortVulnerabilities(vulns []*Vulnerability, by VulnerabilitySort) {
+	sort.SliceStable(vulns, func(i, j int) bool {
+		a, b := vulns[i], vulns[j]
+
+		switch by {
+		case SortByPackage:
+			if a.Package != b.Package {
+				return a.Package < b.Package
+			}
+		case SortByFixedVersion:
+			if (a.FixedVersion == "") != (b.FixedVersion == "") {
+				return a.FixedVersion != ""
+			}
+			if a.FixedVersion != b.FixedVersion {
+				return a.FixedVersion < b.FixedVersion
+			}
+		}
+
+		if SeverityRank(a.Severity) != SeverityRank(b.Severity) {
+			return SeverityRank(a.Severity) < SeverityRank(b.Severity)
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.ID < b.ID
+	})
+}
+
+// the bits of trivy's JSON report we care about
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string
+			PkgName          string
+			InstalledVersion string
+			FixedVersion     string
+			Severity         string
+			Title            string
+		}
+	}
+}
+
+// the bits of grype's JSON report we care about
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+// ParseVulnerabilities parses a trivy or grype JSON report into a list of
+// vulnerabilities, most severe first. Trivy reports a vulnerability once per
+// place it finds the package, so we drop the duplicates.
+func ParseVulnerabilities(output []byte) ([]*Vulnerability, error) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(output, &keys); err != nil {
+		return nil, fmt.Errorf("could not parse scanner output as JSON: %w", err)
+	}
+
+	vulns := []*Vulnerability{}
+
+	switch {
+	case keys["Results"] != nil || keys["SchemaVersion"] != nil:
+		var report trivyReport
+		if err := json.Unmarshal(output, &report); err != nil {
+			return nil, err
+		}
+		for _, result := range report.Results {
+			for _, vuln := range result.Vulnerabilities {
+				vulns = append(vulns, &Vulnerability{
+					ID:               vuln.VulnerabilityID,
+					Severity:         strings.ToUpper(vuln.Severity),
+					Package:          vuln.PkgName,
+					InstalledVersion: vuln.InstalledVersion,
+					FixedVersion:     vuln.FixedVersion,
+					Title:            vuln.Title,
+				})
+			}
+		}
+	case keys["matches"] != nil:
+		var report grypeReport
+		if err := json.Unmarshal(output, &report); err != nil {
+			return nil, err
+		}
+		for _, match := range report.Matches {
+			vulns = append(vulns, &Vulnerability{
+				ID:               match.Vulnerability.ID,
+				Severity:         strings.ToUpper(match.Vulnerability.Severity),
+				Package:          match.Artifact.Name,
+				InstalledVersion: match.Artifact.Version,
+				FixedVersion:     strings.Join(match.Vulnerability.Fix.Versions, ", "),
+				Title:            match.Vulnerability.Description,
+			})
+		}
+	default:
+		return nil, errors.New("scanner output is neither a trivy nor a grype JSON report")
+	}
+
+	vulns = lo.UniqBy(vulns, func(vuln *Vulnerability) string {
+		return vuln.ID + "\x00" + vuln.Package + "\x00" + vuln.InstalledVersion
+	})
+	for _, vuln := range vulns {
+		if vuln.Severity == "" {
+			vuln.Severity = SeverityUnknown
+		}
+	}
+	SortVulnerabilities(vulns, SortBySeverity)
+
+	return vulns, nil
+}
+
+// ScanImage runs the scanImage command template against the image and stores
+// the report in the vulnerabilities cache, replacing any earlier one
+func (c *DockerCommand) ScanImage(ctx context.Context, image *Image) (*VulnerabilityReport, error) {
+	template := c.Config.UserConfig.CommandTemplates.ScanImage
+	if template == "" {
+		return nil, errors.New(c.Tr.ScanImageNotConfigured)
+	}
+
+	command := utils.ApplyTemplate(template, c.NewCommandObject(CommandObject{Image: image}))
+	output, err := c.OSCommand.RunCommandWithOutputContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	vulns, err := ParseVulnerabilities([]byte(output))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VulnerabilityReport{
+		ImageID:         image.ID,
+		ScannedAt:       time.Now(),
+		Vulnerabilities: vulns,
+	}
+
+	c.VulnerabilityReportsMutex.Lock()
+	c.vulnerabilityReports[image.ID] = report
+	c.VulnerabilityReportsMutex.Unlock()
+
+	if err := c.storeVulnerabilityReport(report); err != nil {
+		// the report is still cached in memory, so we can carry on
+		c.Log.Error(err)
+	}
+
+	return report, nil
+}
+
+// VulnerabilityReport returns the last report we got from scanning the image,
+// or nil if we haven't scanned it. Image IDs are content hashes, so a report
+// never goes stale unless the scanner's database has since been updated.
+func (c *DockerCommand) VulnerabilityReport(imageID string) *VulnerabilityReport {
+	c.VulnerabilityReportsMutex.Lock()
+	defer c.VulnerabilityReportsMutex.Unlock()
+
+	report, ok := c.vulnerabilityReports[imageID]
+	if !ok {
+		// we remember that there's no report on disk too, so that we don't look
+		// for one every time we render the images panel
+		report = c.loadVulnerabilityReport(imageID)
+		c.vulnerabilityReports[imageID] = report
+	}
+
+	return report
+}
+
+// ForgetVulnerabilityReport drops the image's report, so that it's scanned
+// afresh the next time we need its report
+func (c *DockerCommand) ForgetVulnerabilityReport(imageID string) error {
+	c.VulnerabilityReportsMutex.Lock()
+	defer c.VulnerabilityReportsMutex.Unlock()
+
+	c.vulnerabilityReports[imageID] = nil
+
+	if err := os.Remove(c.vulnerabilityReportPath(imageID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// vulnerabilityReportPath returns where we cache the report for an image
+func (c *DockerCommand) vulnerabilityReportPath(imageID string) string {
+	return filepath.Join(c.Config.ConfigDir, "vulnerabilities", strings.TrimPrefix(imageID, "sha256:")+".json")
+}
+
+func (c *DockerCommand) loadVulnerabilityReport(imageID string) *VulnerabilityReport {
+	content, err := os.ReadFile(c.vulnerabilityReportPath(imageID))
+	if err != nil {
+		return nil
+	}
+
+	var report VulnerabilityReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+
+	return &report
+}
+
+func (c *DockerCommand) storeVulnerabilityReport(report *VulnerabilityReport) error {
+	path := c.vulnerabilityReportPath(report.ImageID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
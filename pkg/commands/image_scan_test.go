@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseVulnerabilities is a function.
+func TestParseVulnerabilities(t *testing.T) {
+	type scenario struct {
+		name     string
+		output   string
+		expected []*Vulnerability
+		hasError bool
+	}
+
+	scenarios := []scenario{
+		{
+			name: "trivy",
+			output: `{
+				"SchemaVersion": 2,
+				"Results": [
+					{
+						"Target": "app (debian 12.5)",
+						"Vulnerabilities": [
+							{"VulnerabilityID": "CVE-2024-2", "PkgName": "zlib", "InstalledVersion": "1.2", "Severity": "MEDIUM", "Title": "zlib bug"},
+							{"VulnerabilityID": "CVE-2024-1", "PkgName": "openssl", "InstalledVersion": "3.0.1", "FixedVersion": "3.0.2", "Severity": "CRITICAL", "Title": "openssl bug"}
+						]
+					},
+					{
+						"Target": "usr/lib/app.jar",
+						"Vulnerabilities": [
+							{"VulnerabilityID": "CVE-2024-1", "PkgName": "openssl", "InstalledVersion": "3.0.1", "FixedVersion": "3.0.2", "Severity": "CRITICAL", "Title": "openssl bug"},
+							{"VulnerabilityID": "GHSA-1", "PkgName": "log4j", "InstalledVersion": "2.0"}
+						]
+					},
+					{"Target": "no vulnerabilities here"}
+				]
+			}`,
+			expected: []*Vulnerability{
+				{ID: "CVE-2024-1", Severity: SeverityCritical, Package: "openssl", InstalledVersion: "3.0.1", FixedVersion: "3.0.2", Title: "openssl bug"},
+				{ID: "CVE-2024-2", Severity: SeverityMedium, Package: "zlib", InstalledVersion: "1.2", Title: "zlib bug"},
+				{ID: "GHSA-1", Severity: SeverityUnknown, Package: "log4j", InstalledVersion: "2.0"},
+			},
+		},
+		{
+			name: "trivy without vulnerabilities",
+			output: `{
+				"SchemaVersion": 2,
+				"Results": null
+			}`,
+			expected: []*Vulnerability{},
+		},
+		{
+			name: "grype",
+			output: `{
+				"matches": [
+					{
+						"vulnerability": {"id": "CVE-2024-3", "severity": "Negligible", "description": "curl bug", "fix": {"versions": [], "state": "not-fixed"}},
+						"artifact": {"name": "curl", "version": "8.0"}
+					},
+					{
+						"vulnerability": {"id": "CVE-2024-4", "severity": "High", "description": "glibc bug", "fix": {"versions": ["2.36-9", "2.37"], "state": "fixed"}},
+						"artifact": {"name": "glibc", "version": "2.36-8"}
+					}
+				],
+				"descriptor": {"name": "grype"}
+			}`,
+			expected: []*Vulnerability{
+				{ID: "CVE-2024-4", Severity: SeverityHigh, Package: "glibc", InstalledVersion: "2.36-8", FixedVersion: "2.36-9, 2.37", Title: "glibc bug"},
+				{ID: "CVE-2024-3", Severity: SeverityNegligible, Package: "curl", InstalledVersion: "8.0", Title: "curl bug"},
+			},
+		},
+		{
+			name:     "not a report",
+			output:   `{"foo": "bar"}`,
+			hasError: true,
+		},
+		{
+			name:     "not JSON",
+			output:   "FATAL: image not found",
+			hasError: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := ParseVulnerabilities([]byte(s.output))
+			if s.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+}
+
+// TestSortVulnerabilities is a function.
+func TestSortVulnerabilities(t *testing.T) {
+	vulns := []*Vulnerability{
+		{ID: "a", Severity: SeverityLow, Package: "zlib", FixedVersion: "1.3"},
+		{ID: "b", Severity: SeverityCritical, Package: "openssl"},
+		{ID: "c", Severity: SeverityHigh, Package: "curl", FixedVersion: "8.1"},
+		{ID: "d", Severity: SeverityHigh, Package: "bash"},
+		{ID: "e", Severity: "WHATEVER", Package: "zlib"},
+	}
+
+	type scenario struct {
+		by       VulnerabilitySort
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{SortBySeverity, []string{"b", "d", "c", "a", "e"}},
+		{SortByPackage, []string{"d", "c", "b", "a", "e"}},
+		{SortByFixedVersion, []string{"a", "c", "b", "d", "e"}},
+	}
+
+	for _, s := range scenarios {
+		SortVulnerabilities(vulns, s.by)
+		assert.EqualValues(t, s.expected, lo.Map(vulns, func(vuln *Vulnerability, _ int) string { return vuln.ID }))
+	}
+}
+
+// TestVulnerabilityReportCache is a function.
+func TestVulnerabilityReportCache(t *testing.T) {
+	configDir := t.TempDir()
+	newDockerCommand := func() *DockerCommand {
+		appConfig := NewDummyAppConfig()
+		appConfig.ConfigDir = configDir
+		return &DockerCommand{
+			Log:                  NewDummyLog(),
+			Config:               appConfig,
+			vulnerabilityReports: map[string]*VulnerabilityReport{},
+		}
+	}
+
+	dockerCommand := newDockerCommand()
+	assert.Nil(t, dockerCommand.VulnerabilityReport("sha256:abc"))
+
+	report := &VulnerabilityReport{
+		ImageID:         "sha256:abc",
+		ScannedAt:       time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Vulnerabilities: []*Vulnerability{{ID: "CVE-2024-1", Severity: SeverityHigh, Package: "openssl"}},
+	}
+	assert.NoError(t, dockerCommand.storeVulnerabilityReport(report))
+
+	// the first DockerCommand remembers that there was no report
+	assert.Nil(t, dockerCommand.VulnerabilityReport("sha256:abc"))
+
+	// but a fresh one finds the report on disk
+	dockerCommand = newDockerCommand()
+	assert.EqualValues(t, report, dockerCommand.VulnerabilityReport("sha256:abc"))
+}
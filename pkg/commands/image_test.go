@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImageContainers is a function.
+func TestImageContainers(t *testing.T) {
+	web := &Container{Name: "web", Container: container.Summary{ImageID: "sha256:aaa"}}
+	worker := &Container{Name: "worker", Container: container.Summary{ImageID: "sha256:aaa"}}
+	db := &Container{Name: "db", Container: container.Summary{ImageID: "sha256:bbb"}}
+
+	img := &Image{ID: "sha256:aaa"}
+
+	assert.EqualValues(t, []*Container{web, worker}, img.Containers([]*Container{web, db, worker}))
+	assert.Empty(t, img.Containers([]*Container{db}))
+}
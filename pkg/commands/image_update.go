@@ -0,0 +1,359 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	"github.com/samber/lo"
+)
+
+// ImageUpdate is what we found the last time we asked a registry whether there
+// was a newer version of a container's image
+type ImageUpdate struct {
+	// Ref is the repository:tag reference we checked
+	Ref string
+	// RemoteDigest is the digest the registry has for the reference
+	RemoteDigest string
+	// Available is true if the registry's digest isn't one of the digests of
+	// the container's image
+	Available bool
+	CheckedAt time.Time
+	// Err is why we couldn't check, if we couldn't
+	Err error
+}
+
+// UpdateCheckRef returns the repository:tag reference a container was created
+// from, which is what we check for updates. Containers created from an image
+// ID or a digest have no tag that could move, so we don't check them.
+func UpdateCheckRef(image string) (string, bool) {
+	if strings.HasPrefix(image, "sha256:") {
+		return "", false
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", false
+	}
+	if _, ok := named.(reference.Canonical); ok {
+		return "", false
+	}
+
+	return reference.FamiliarString(reference.TagNameOnly(named)), true
+}
+
+// hasRepoDigest tells us whether one of an image's repo digests (e.g.
+// 'nginx@sha256:...') is the given digest in the given reference's repository
+func hasRepoDigest(repoDigests []string, ref string, digest string) bool {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return false
+	}
+
+	return lo.SomeBy(repoDigests, func(repoDigest string) bool {
+		canonical, err := reference.ParseNormalizedNamed(repoDigest)
+		if err != nil {
+			return false
+		}
+		withDigest, ok := canonical.(reference.Canonical)
+		return ok && canonical.Name() == named.Name() && withDigest.Digest().String() == digest
+	})
+}
+
+// the key we store a container's update check under. An update check only
+// holds for the image the container was using at the time.
+func imageUpdateKey(ctr *Container) string {
+	return ctr.Container.Image + "@" + ctr.Container.ImageID
+}
+
+// ImageUpdate returns the result of the last update check for the container's
+// image, or nil if we haven't checked it
+func (c *DockerCommand) ImageUpdate(ctr *Container) *ImageUpdate {
+	c.ImageUpdatesMutex.Lock()
+	defer c.ImageUpdatesMutex.Unlock()
+
+	return c.imageUpdates[imageUpdateKey(ctr)]
+}
+
+// CheckImageUpdates asks the registries whether there are newer versions of
+// the given containers' images, skipping those we checked less than the
+// configured interval ago. We ask about each reference once, waiting between
+// requests so that we don't run into the registries' rate limits.
+func (c *DockerCommand) CheckImageUpdates(ctx context.Context, containers []*Container) {
+	c.checkImageUpdates(ctx, containers, c.remoteDigest, c.imageOutdated)
+}
+
+// checkImageUpdates is CheckImageUpdates with the registry and image lookups
+// passed in, so that we can test it without a docker daemon
+func (c *DockerCommand) checkImageUpdates(
+	ctx context.Context,
+	containers []*Container,
+	remoteDigest func(ctx context.Context, ref string) (string, error),
+	imageOutdated func(ctx context.Context, imageID string, ref string, remoteDigest string) (bool, error),
+) {
+	config := c.Config.UserConfig.UpdateCheck
+
+	c.ImageUpdatesMutex.Lock()
+	due := lo.Filter(containers, func(ctr *Container, _ int) bool {
+		if _, ok := UpdateCheckRef(ctr.Container.Image); !ok {
+			return false
+		}
+		update, ok := c.imageUpdates[imageUpdateKey(ctr)]
+		return !ok || time.Since(update.CheckedAt) >= config.Interval
+	})
+	c.ImageUpdatesMutex.Unlock()
+
+	refOf := func(ctr *Container) string {
+		ref, _ := UpdateCheckRef(ctr.Container.Image)
+		return ref
+	}
+	byRef := lo.GroupBy(due, refOf)
+
+	first := true
+	for _, ref := range lo.Uniq(lo.Map(due, func(ctr *Container, _ int) string { return refOf(ctr) })) {
+		if !first {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(config.RequestDelay):
+			}
+		}
+		first = false
+
+		digest, err := remoteDigest(ctx, ref)
+		if ctx.Err() != nil {
+			return
+		}
+
+		for _, ctr := range byRef[ref] {
+			update := &ImageUpdate{Ref: ref, RemoteDigest: digest, CheckedAt: time.Now(), Err: err}
+			if err == nil {
+				update.Available, update.Err = imageOutdated(ctx, ctr.Container.ImageID, ref, digest)
+			}
+			if update.Err != nil {
+				c.Log.Warn(fmt.Sprintf("could not check %s for updates: %s", ref, update.Err))
+			}
+
+			c.ImageUpdatesMutex.Lock()
+			c.imageUpdates[imageUpdateKey(ctr)] = update
+			c.ImageUpdatesMutex.Unlock()
+		}
+	}
+}
+
+// remoteDigest returns the digest the registry has for the given reference.
+// For multi-platform images that's the digest of the image index, which is
+// also what docker records as the repo digest when pulling by tag.
+func (c *DockerCommand) remoteDigest(ctx context.Context, ref string) (string, error) {
+	auth, err := registryAuth(ref)
+	if err != nil {
+		return "", err
+	}
+
+	distribution, err := c.Client.DistributionInspect(ctx, ref, auth)
+	if err != nil {
+		return "", err
+	}
+
+	return distribution.Descriptor.Digest.String(), nil
+}
+
+// imageOutdated tells us whether the given local image is out of date with the
+// registry's digest for the reference
+func (c *DockerCommand) imageOutdated(ctx context.Context, imageID string, ref string, remoteDigest string) (bool, error) {
+	inspect, err := c.Client.ImageInspect(ctx, imageID)
+	if err != nil {
+		return false, err
+	}
+
+	// an image we built ourselves and never pushed or pulled has no repo
+	// digests, so there's nothing to compare against
+	if len(inspect.RepoDigests) == 0 {
+		return false, fmt.Errorf("%s has no repo digests", ref)
+	}
+
+	return !hasRepoDigest(inspect.RepoDigests, ref, remoteDigest), nil
+}
+
+// Recreate replaces the container with a new one made from the image it was
+// created from, with the same config. This is how a container picks up a newer
+// version of its image, so we only carry over the parts of the config that the
+// user set, leaving the rest to the new image. The container's anonymous
+// volumes hold its data, so the new container mounts the same ones. If we
+// can't create the new container we put the old one back.
+func (c *Container) Recreate(ctx context.Context) error {
+	c.Log.Warn(fmt.Sprintf("recreating container %s", c.Name))
+
+	details, err := c.Client.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	oldImage, err := c.Client.ImageInspect(ctx, details.Image)
+	if err != nil {
+		return err
+	}
+
+	config := userContainerConfig(details.Config, oldImage.Config)
+	// the hostname defaults to the container's short ID, which the new
+	// container should have its own of
+	if config.Hostname == c.ID[:12] {
+		config.Hostname = ""
+	}
+
+	hostConfig := *details.HostConfig
+	hostConfig.Mounts = append(append([]mount.Mount{}, hostConfig.Mounts...), anonymousVolumeMounts(details)...)
+
+	networkingConfig := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{}}
+	for name, endpoint := range details.NetworkSettings.Networks {
+		networkingConfig.EndpointsConfig[name] = &network.EndpointSettings{
+			IPAMConfig: endpoint.IPAMConfig,
+			Links:      endpoint.Links,
+			Aliases: lo.Filter(endpoint.Aliases, func(alias string, _ int) bool {
+				return alias != c.ID[:12]
+			}),
+			DriverOpts: endpoint.DriverOpts,
+			GwPriority: endpoint.GwPriority,
+		}
+	}
+
+	if details.State.Running {
+		if err := c.Client.ContainerStop(ctx, c.ID, container.StopOptions{}); err != nil {
+			return err
+		}
+	}
+
+	// we keep the old container around under another name until the new one
+	// exists, so that we can restore it if need be
+	oldName := c.Name + "-lazydocker-old"
+	if err := c.Client.ContainerRename(ctx, c.ID, oldName); err != nil {
+		return err
+	}
+
+	restore := func(err error) error {
+		_ = c.Client.ContainerRename(ctx, c.ID, c.Name)
+		if details.State.Running {
+			_ = c.Client.ContainerStart(ctx, c.ID, container.StartOptions{})
+		}
+		return err
+	}
+
+	created, err := c.Client.ContainerCreate(ctx, config, &hostConfig, networkingConfig, nil, c.Name)
+	if err != nil {
+		return restore(err)
+	}
+
+	if details.State.Running {
+		if err := c.Client.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+			_ = c.Client.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true})
+			return restore(err)
+		}
+	}
+
+	// the new container has the anonymous volumes now, so we leave them be
+	return c.Client.ContainerRemove(ctx, c.ID, container.RemoveOptions{})
+}
+
+// userContainerConfig returns a container's config without what it inherited
+// from its image, leaving only what the user set when creating it. A config
+// from inspecting a container has the image's env, command and so on merged
+// in, which would otherwise override the defaults of a newer image.
+func userContainerConfig(config *container.Config, image *dockerspec.DockerOCIImageConfig) *container.Config {
+	result := *config
+	if image == nil {
+		return &result
+	}
+
+	imageEnv := lo.SliceToMap(image.Env, func(env string) (string, bool) { return env, true })
+	result.Env = lo.Filter(config.Env, func(env string, _ int) bool { return !imageEnv[env] })
+
+	// docker drops the image's command when given an entrypoint, so the
+	// command is only inherited if the entrypoint is
+	if slices.Equal(config.Entrypoint, image.Entrypoint) {
+		result.Entrypoint = nil
+		if slices.Equal(config.Cmd, image.Cmd) {
+			result.Cmd = nil
+		}
+	}
+
+	if config.WorkingDir == image.WorkingDir {
+		result.WorkingDir = ""
+	}
+	if config.User == image.User {
+		result.User = ""
+	}
+	if config.StopSignal == image.StopSignal {
+		result.StopSignal = ""
+	}
+
+	result.Labels = lo.OmitBy(config.Labels, func(key string, value string) bool {
+		imageValue, ok := image.Labels[key]
+		return ok && imageValue == value
+	})
+	result.ExposedPorts = lo.OmitBy(config.ExposedPorts, func(port nat.Port, _ struct{}) bool {
+		_, ok := image.ExposedPorts[string(port)]
+		return ok
+	})
+	result.Volumes = lo.OmitBy(config.Volumes, func(volume string, _ struct{}) bool {
+		_, ok := image.Volumes[volume]
+		return ok
+	})
+
+	if config.Healthcheck != nil && image.Healthcheck != nil &&
+		slices.Equal(config.Healthcheck.Test, image.Healthcheck.Test) &&
+		config.Healthcheck.Interval == image.Healthcheck.Interval &&
+		config.Healthcheck.Timeout == image.Healthcheck.Timeout &&
+		config.Healthcheck.StartPeriod == image.Healthcheck.StartPeriod &&
+		config.Healthcheck.StartInterval == image.Healthcheck.StartInterval &&
+		config.Healthcheck.Retries == image.Healthcheck.Retries {
+		result.Healthcheck = nil
+	}
+
+	return &result
+}
+
+// anonymousVolumeMounts returns mounts of the container's anonymous volumes,
+// i.e. the volumes docker created for its VOLUME paths which the user didn't
+// mount anything else at
+func anonymousVolumeMounts(details container.InspectResponse) []mount.Mount {
+	explicitTargets := map[string]bool{}
+	if details.HostConfig != nil {
+		for _, m := range details.HostConfig.Mounts {
+			explicitTargets[path.Clean(m.Target)] = true
+		}
+	}
+
+	// binds are 'source:target[:options]', but the source may be a Windows path
+	// with a colon of its own, so rather than parse them we look for volumes
+	// named as their source. Docker names anonymous volumes randomly, so any
+	// volume named in a bind was mounted by the user.
+	namedInBind := func(name string) bool {
+		if details.HostConfig == nil {
+			return false
+		}
+		return lo.SomeBy(details.HostConfig.Binds, func(bind string) bool {
+			return strings.HasPrefix(bind, name+":")
+		})
+	}
+
+	return lo.FilterMap(details.Mounts, func(mountPoint container.MountPoint, _ int) (mount.Mount, bool) {
+		if mountPoint.Type != mount.TypeVolume || mountPoint.Name == "" || explicitTargets[path.Clean(mountPoint.Destination)] || namedInBind(mountPoint.Name) {
+			return mount.Mount{}, false
+		}
+		return mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   mountPoint.Name,
+			Target:   mountPoint.Destination,
+			ReadOnly: !mountPoint.RW,
+		}, true
+	})
+}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpdateCheckRef is a function.
+func TestUpdateCheckRef(t *testing.T) {
+	type scenario struct {
+		image       string
+		expectedRef string
+		expectedOk  bool
+	}
+
+	scenarios := []scenario{
+		{"nginx", "nginx:latest", true},
+		{"nginx:1.25", "nginx:1.25", true},
+		{"docker.io/library/nginx:1.25", "nginx:1.25", true},
+		{"localhost:5000/app", "localhost:5000/app:latest", true},
+		{"localhost:5000/app:v2", "localhost:5000/app:v2", true},
+		{"nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000", "", false},
+		{"sha256:0000000000000000000000000000000000000000000000000000000000000000", "", false},
+		{"Not A Reference", "", false},
+	}
+
+	for _, s := range scenarios {
+		ref, ok := UpdateCheckRef(s.image)
+		assert.EqualValues(t, s.expectedRef, ref, s.image)
+		assert.EqualValues(t, s.expectedOk, ok, s.image)
+	}
+}
+
+// TestHasRepoDigest is a function.
+func TestHasRepoDigest(t *testing.T) {
+	digestA := "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	digestB := "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	type scenario struct {
+		name        string
+		repoDigests []string
+		ref         string
+		digest      string
+		expected    bool
+	}
+
+	scenarios := []scenario{
+		{"same digest", []string{"nginx@" + digestA}, "nginx:latest", digestA, true},
+		{"registry has a newer digest", []string{"nginx@" + digestA}, "nginx:latest", digestB, false},
+		{"familiar and full names match", []string{"docker.io/library/nginx@" + digestA}, "nginx:1.25", digestA, true},
+		{"same digest in another repository", []string{"mirror.local/nginx@" + digestA}, "nginx:latest", digestA, false},
+		{"image has several repo digests", []string{"mirror.local/nginx@" + digestB, "nginx@" + digestA}, "nginx:latest", digestA, true},
+		{"local registry", []string{"localhost:5000/app@" + digestA}, "localhost:5000/app:v2", digestA, true},
+		{"no repo digests", nil, "nginx:latest", digestA, false},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, hasRepoDigest(s.repoDigests, s.ref, s.digest), s.name)
+	}
+}
+
+// TestUserContainerConfig is a function.
+func TestUserContainerConfig(t *testing.T) {
+	image := &dockerspec.DockerOCIImageConfig{
+		ImageConfig: ocispec.ImageConfig{
+			Env:          []string{"PATH=/usr/bin", "VERSION=1"},
+			Entrypoint:   []string{"/entrypoint.sh"},
+			Cmd:          []string{"postgres"},
+			WorkingDir:   "/data",
+			Labels:       map[string]string{"maintainer": "someone", "version": "1"},
+			ExposedPorts: map[string]struct{}{"5432/tcp": {}},
+			Volumes:      map[string]struct{}{"/var/lib/postgresql/data": {}},
+		},
+	}
+
+	config := &container.Config{
+		Image:        "postgres:16",
+		Env:          []string{"PATH=/usr/bin", "VERSION=1", "POSTGRES_PASSWORD=secret"},
+		Entrypoint:   []string{"/entrypoint.sh"},
+		Cmd:          []string{"postgres"},
+		WorkingDir:   "/data",
+		Labels:       map[string]string{"maintainer": "someone", "version": "1", "app": "db"},
+		ExposedPorts: nat.PortSet{"5432/tcp": {}, "9187/tcp": {}},
+		Volumes:      map[string]struct{}{"/var/lib/postgresql/data": {}},
+	}
+
+	result := userContainerConfig(config, image)
+
+	assert.EqualValues(t, "postgres:16", result.Image)
+	assert.EqualValues(t, []string{"POSTGRES_PASSWORD=secret"}, result.Env)
+	assert.Nil(t, result.Entrypoint)
+	assert.Nil(t, result.Cmd)
+	assert.EqualValues(t, "", result.WorkingDir)
+	assert.EqualValues(t, map[string]string{"app": "db"}, result.Labels)
+	assert.EqualValues(t, nat.PortSet{"9187/tcp": {}}, result.ExposedPorts)
+	assert.Empty(t, result.Volumes)
+
+	// a command the user passed is kept, and so is the image's command when
+	// the user changed the entrypoint, as docker wouldn't have inherited it
+	config.Cmd = []string{"postgres", "-c", "fsync=off"}
+	assert.EqualValues(t, []string{"postgres", "-c", "fsync=off"}, userContainerConfig(config, image).Cmd)
+
+	config.Entrypoint = []string{"/custom.sh"}
+	config.Cmd = []string{"postgres"}
+	result = userContainerConfig(config, image)
+	assert.EqualValues(t, []string{"/custom.sh"}, result.Entrypoint)
+	assert.EqualValues(t, []string{"postgres"}, result.Cmd)
+}
+
+// TestAnonymousVolumeMounts is a function.
+func TestAnonymousVolumeMounts(t *testing.T) {
+	details := container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				Binds:  []string{"/srv/config:/etc/app:ro", `C:\data:/data`, "shared:/shared/:ro"},
+				Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: "named", Target: "/named"}},
+			},
+		},
+		Mounts: []container.MountPoint{
+			{Type: mount.TypeBind, Source: "/srv/config", Destination: "/etc/app"},
+			{Type: mount.TypeBind, Source: `C:\data`, Destination: "/data", RW: true},
+			{Type: mount.TypeVolume, Name: "shared", Destination: "/shared", RW: false},
+			{Type: mount.TypeVolume, Name: "named", Destination: "/named", RW: true},
+			{Type: mount.TypeVolume, Name: "0123abcd", Destination: "/var/lib/postgresql/data", RW: true},
+			{Type: mount.TypeVolume, Name: "4567cdef", Destination: "/cache/", RW: false},
+			{Type: mount.TypeTmpfs, Destination: "/tmp"},
+		},
+	}
+
+	assert.EqualValues(t, []mount.Mount{
+		{Type: mount.TypeVolume, Source: "0123abcd", Target: "/var/lib/postgresql/data"},
+		{Type: mount.TypeVolume, Source: "4567cdef", Target: "/cache/", ReadOnly: true},
+	}, anonymousVolumeMounts(details))
+}
+
+// TestCheckImageUpdates is a function.
+func TestCheckImageUpdates(t *testing.T) {
+	dockerCommand := NewDummyDockerCommand()
+	dockerCommand.Config.UserConfig.UpdateCheck.Interval = time.Hour
+	dockerCommand.Config.UserConfig.UpdateCheck.RequestDelay = 50 * time.Millisecond
+
+	newContainer := func(id string, image string) *Container {
+		return &Container{ID: id, Container: container.Summary{Image: image, ImageID: "sha256:" + id}}
+	}
+	web1 := newContainer("web1", "nginx")
+	web2 := newContainer("web2", "nginx:latest")
+	db := newContainer("db", "postgres:16")
+	pinned := newContainer("pinned", "redis@sha256:0000000000000000000000000000000000000000000000000000000000000000")
+
+	var requests []string
+	var requestTimes []time.Time
+	remoteDigest := func(_ context.Context, ref string) (string, error) {
+		requests = append(requests, ref)
+		requestTimes = append(requestTimes, time.Now())
+		return "sha256:remote", nil
+	}
+	imageOutdated := func(_ context.Context, imageID string, _ string, _ string) (bool, error) {
+		return imageID == "sha256:db", nil
+	}
+
+	containers := []*Container{web1, web2, db, pinned}
+	dockerCommand.checkImageUpdates(context.Background(), containers, remoteDigest, imageOutdated)
+
+	// we ask about each reference once, and not about pinned images at all
+	assert.EqualValues(t, []string{"nginx:latest", "postgres:16"}, requests)
+	assert.GreaterOrEqual(t, requestTimes[1].Sub(requestTimes[0]), 50*time.Millisecond)
+
+	assert.False(t, dockerCommand.ImageUpdate(web1).Available)
+	assert.False(t, dockerCommand.ImageUpdate(web2).Available)
+	assert.True(t, dockerCommand.ImageUpdate(db).Available)
+	assert.Nil(t, dockerCommand.ImageUpdate(pinned))
+
+	// within the interval we don't ask again
+	requests = nil
+	dockerCommand.checkImageUpdates(context.Background(), containers, remoteDigest, imageOutdated)
+	assert.Empty(t, requests)
+
+	// once the interval has passed we do
+	dockerCommand.ImageUpdate(db).CheckedAt = time.Now().Add(-2 * time.Hour)
+	dockerCommand.checkImageUpdates(context.Background(), containers, remoteDigest, imageOutdated)
+	assert.EqualValues(t, []string{"postgres:16"}, requests)
+
+	// and a cancelled check stops before the next request
+	dockerCommand.imageUpdates = map[string]*ImageUpdate{}
+	requests = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelling := func(ctx context.Context, ref string) (string, error) {
+		cancel()
+		return remoteDigest(ctx, ref)
+	}
+	dockerCommand.checkImageUpdates(ctx, containers, cancelling, imageOutdated)
+	assert.EqualValues(t, []string{"nginx:latest"}, requests)
+}
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
+	"strings"
 
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/sirupsen/logrus"
@@ -42,10 +44,151 @@ func (c *DockerCommand) RefreshNetworks() ([]*Network, error) {
 	return ownNetworks, nil
 }
 
-// PruneNetworks prunes networks
-func (c *DockerCommand) PruneNetworks() error {
-	_, err := c.Client.NetworksPrune(context.Background(), filters.Args{})
-	return err
+// NetworkCreateOptions are the options for creating a network
+type NetworkCreateOptions struct {
+	Name   string
+	Driver string
+	// Subnets, Gateways and IPRanges are in CIDR notation, apart from gateways
+	// which are plain addresses. Each gateway and IP range goes with the subnet
+	// containing it.
+	Subnets    []string
+	Gateways   []string
+	IPRanges   []string
+	Internal   bool
+	Attachable bool
+	EnableIPv6 bool
+	Labels     map[string]string
+}
+
+// ParseList parses a comma or space separated list, e.g. of subnets
+func ParseList(str string) []string {
+	return strings.FieldsFunc(str, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// HasIPv6Subnet tells us whether any of the given subnets are IPv6 ones
+func HasIPv6Subnet(subnets []string) bool {
+	for _, subnet := range subnets {
+		if prefix, err := netip.ParsePrefix(subnet); err == nil && prefix.Addr().Is6() {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkIPAMConfig validates the subnets, gateways and IP ranges of a network
+// we're about to create, returning them as the IPAM config docker expects.
+// Like `docker network create`, we match each gateway and IP range with the
+// subnet containing it.
+func NetworkIPAMConfig(subnets []string, gateways []string, ipRanges []string, enableIPv6 bool) ([]network.IPAMConfig, error) {
+	prefixes := make([]netip.Prefix, len(subnets))
+	configs := make([]network.IPAMConfig, len(subnets))
+	for i, subnet := range subnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %s: expected CIDR notation e.g. 172.28.0.0/16", subnet)
+		}
+		if prefix.Addr().Is6() && !enableIPv6 {
+			return nil, fmt.Errorf("subnet %s is an IPv6 subnet, which requires IPv6 to be enabled", subnet)
+		}
+		for j := 0; j < i; j++ {
+			if prefixes[j].Overlaps(prefix) {
+				return nil, fmt.Errorf("subnets %s and %s overlap", subnets[j], subnet)
+			}
+		}
+		prefixes[i] = prefix
+		configs[i] = network.IPAMConfig{Subnet: subnet}
+	}
+
+	// subnetFor returns the index of the subnet containing the given address
+	subnetFor := func(kind string, value string, addr netip.Addr) (int, error) {
+		for i, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%s %s isn't in any of the subnets", kind, value)
+	}
+
+	for _, gateway := range gateways {
+		addr, err := netip.ParseAddr(gateway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway %s: expected an IP address", gateway)
+		}
+		i, err := subnetFor("gateway", gateway, addr)
+		if err != nil {
+			return nil, err
+		}
+		if configs[i].Gateway != "" {
+			return nil, fmt.Errorf("subnet %s can only have one gateway", subnets[i])
+		}
+		configs[i].Gateway = gateway
+	}
+
+	for _, ipRange := range ipRanges {
+		prefix, err := netip.ParsePrefix(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %s: expected CIDR notation", ipRange)
+		}
+		i, err := subnetFor("IP range", ipRange, prefix.Addr())
+		if err != nil {
+			return nil, err
+		}
+		if configs[i].IPRange != "" {
+			return nil, fmt.Errorf("subnet %s can only have one IP range", subnets[i])
+		}
+		configs[i].IPRange = ipRange
+	}
+
+	// docker checks the rest, e.g. that a subnet has no host bits set and an IP
+	// range fits inside its subnet
+	if err := network.ValidateIPAM(&network.IPAM{Config: configs}, enableIPv6); err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+// CreateNetwork creates a network, returning its ID
+func (c *DockerCommand) CreateNetwork(options NetworkCreateOptions) (string, error) {
+	c.Log.Warn(fmt.Sprintf("creating network %s", options.Name))
+
+	createOptions, err := networkCreateOptions(options)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := c.Client.NetworkCreate(context.Background(), options.Name, createOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return response.ID, nil
+}
+
+func networkCreateOptions(options NetworkCreateOptions) (network.CreateOptions, error) {
+	ipamConfig, err := NetworkIPAMConfig(options.Subnets, options.Gateways, options.IPRanges, options.EnableIPv6)
+	if err != nil {
+		return network.CreateOptions{}, err
+	}
+
+	createOptions := network.CreateOptions{
+		Driver:     options.Driver,
+		Internal:   options.Internal,
+		Attachable: options.Attachable,
+		Labels:     options.Labels,
+	}
+	// leaving IPv6 unset lets the daemon's default apply, which may be to
+	// enable it
+	if options.EnableIPv6 {
+		createOptions.EnableIPv6 = &options.EnableIPv6
+	}
+	if len(ipamConfig) > 0 {
+		createOptions.IPAM = &network.IPAM{Config: ipamConfig}
+	}
+
+	return createOptions, nil
 }
 
 // Remove removes the network
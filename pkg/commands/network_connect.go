@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/samber/lo"
+)
+
+// NetworkEndpoint is a container's connection to a network
+type NetworkEndpoint struct {
+	// NetworkName is the name of the network the container is connected to
+	NetworkName string
+	Container   *Container
+	Settings    *network.EndpointSettings
+}
+
+// NetworkEndpoints returns the container's connections to networks, sorted by
+// network name. We only know these once the container's details are loaded.
+func (c *Container) NetworkEndpoints() []*NetworkEndpoint {
+	if !c.DetailsLoaded() || c.Details.NetworkSettings == nil {
+		return nil
+	}
+
+	endpoints := make([]*NetworkEndpoint, 0, len(c.Details.NetworkSettings.Networks))
+	for name, settings := range c.Details.NetworkSettings.Networks {
+		if settings == nil {
+			continue
+		}
+		endpoints = append(endpoints, &NetworkEndpoint{NetworkName: name, Container: c, Settings: settings})
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].NetworkName < endpoints[j].NetworkName
+	})
+
+	return endpoints
+}
+
+// Endpoints returns the given containers' connections to the network, sorted
+// by container name. Docker doesn't tell us which containers are on a network
+// when listing networks, so we mostly go by what the containers say. If the
+// network has been inspected, we also take the containers it lists, e.g. ones
+// whose details haven't loaded yet.
+func (n *Network) Endpoints(containers []*Container) []*NetworkEndpoint {
+	endpoints := lo.FilterMap(containers, func(ctr *Container, _ int) (*NetworkEndpoint, bool) {
+		if endpoint, ok := lo.Find(ctr.NetworkEndpoints(), n.hasEndpoint); ok {
+			return endpoint, true
+		}
+
+		resource, ok := n.Network.Containers[ctr.ID]
+		if !ok {
+			return nil, false
+		}
+		return &NetworkEndpoint{NetworkName: n.Name, Container: ctr, Settings: endpointSettings(n.Network.ID, resource)}, true
+	})
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Container.Name < endpoints[j].Container.Name
+	})
+
+	return endpoints
+}
+
+// endpointSettings converts what a network says about one of its containers
+// into the form the container would give it in
+func endpointSettings(networkID string, resource network.EndpointResource) *network.EndpointSettings {
+	settings := &network.EndpointSettings{
+		NetworkID:  networkID,
+		EndpointID: resource.EndpointID,
+		MacAddress: resource.MacAddress,
+	}
+	if prefix, err := netip.ParsePrefix(resource.IPv4Address); err == nil {
+		settings.IPAddress, settings.IPPrefixLen = prefix.Addr().String(), prefix.Bits()
+	}
+	if prefix, err := netip.ParsePrefix(resource.IPv6Address); err == nil {
+		settings.GlobalIPv6Address, settings.GlobalIPv6PrefixLen = prefix.Addr().String(), prefix.Bits()
+	}
+	return settings
+}
+
+func (n *Network) hasEndpoint(endpoint *NetworkEndpoint) bool {
+	return endpoint.NetworkName == n.Name || (endpoint.Settings.NetworkID != "" && endpoint.Settings.NetworkID == n.Network.ID)
+}
+
+// IsConnected tells us whether the container is connected to the network
+func (n *Network) IsConnected(ctr *Container) bool {
+	if _, ok := n.Network.Containers[ctr.ID]; ok {
+		return true
+	}
+	return lo.SomeBy(ctr.NetworkEndpoints(), n.hasEndpoint)
+}
+
+// NetworkConnectOptions are the options for connecting a container to a network
+type NetworkConnectOptions struct {
+	Aliases []string
+	// IPv4Address and IPv6Address are static addresses to give the container on
+	// the network. If empty, docker picks one.
+	IPv4Address string
+	IPv6Address string
+}
+
+// NetworkEndpointSettings validates the options for connecting a container to
+// a network, returning them as the endpoint settings docker expects
+func NetworkEndpointSettings(options NetworkConnectOptions) (*network.EndpointSettings, error) {
+	settings := &network.EndpointSettings{Aliases: options.Aliases}
+
+	if options.IPv4Address == "" && options.IPv6Address == "" {
+		return settings, nil
+	}
+
+	if options.IPv4Address != "" {
+		addr, err := netip.ParseAddr(options.IPv4Address)
+		if err != nil || !addr.Is4() {
+			return nil, fmt.Errorf("invalid IPv4 address %s", options.IPv4Address)
+		}
+	}
+	if options.IPv6Address != "" {
+		addr, err := netip.ParseAddr(options.IPv6Address)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return nil, fmt.Errorf("invalid IPv6 address %s", options.IPv6Address)
+		}
+	}
+
+	settings.IPAMConfig = &network.EndpointIPAMConfig{
+		IPv4Address: options.IPv4Address,
+		IPv6Address: options.IPv6Address,
+	}
+
+	return settings, nil
+}
+
+// Connect connects the container to the network
+func (n *Network) Connect(ctr *Container, options NetworkConnectOptions) error {
+	n.Log.Warn(fmt.Sprintf("connecting container %s to network %s", ctr.Name, n.Name))
+
+	settings, err := NetworkEndpointSettings(options)
+	if err != nil {
+		return err
+	}
+
+	return n.Client.NetworkConnect(context.Background(), n.Network.ID, ctr.ID, settings)
+}
+
+// Disconnect disconnects the container from the network
+func (n *Network) Disconnect(ctr *Container) error {
+	n.Log.Warn(fmt.Sprintf("disconnecting container %s from network %s", ctr.Name, n.Name))
+
+	return n.Client.NetworkDisconnect(context.Background(), n.Network.ID, ctr.ID, false)
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNetworkEndpointSettings is a function.
+func TestNetworkEndpointSettings(t *testing.T) {
+	type scenario struct {
+		name     string
+		options  NetworkConnectOptions
+		expected *network.EndpointSettings
+		err      string
+	}
+
+	scenarios := []scenario{
+		{
+			name:     "aliases only",
+			options:  NetworkConnectOptions{Aliases: []string{"db", "postgres"}},
+			expected: &network.EndpointSettings{Aliases: []string{"db", "postgres"}},
+		},
+		{
+			name:    "static addresses",
+			options: NetworkConnectOptions{IPv4Address: "172.28.5.10", IPv6Address: "fd00:28::10"},
+			expected: &network.EndpointSettings{
+				IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.28.5.10", IPv6Address: "fd00:28::10"},
+			},
+		},
+		{
+			name:    "IPv6 address given as the IPv4 one",
+			options: NetworkConnectOptions{IPv4Address: "fd00:28::10"},
+			err:     "invalid IPv4 address fd00:28::10",
+		},
+		{
+			name:    "IPv4 address given as the IPv6 one",
+			options: NetworkConnectOptions{IPv6Address: "172.28.5.10"},
+			err:     "invalid IPv6 address 172.28.5.10",
+		},
+		{
+			name:    "address with a prefix length",
+			options: NetworkConnectOptions{IPv4Address: "172.28.5.10/16"},
+			err:     "invalid IPv4 address 172.28.5.10/16",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := NetworkEndpointSettings(s.options)
+			if s.err != "" {
+				assert.EqualError(t, err, s.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+}
+
+// TestNetworkEndpoints is a function.
+func TestNetworkEndpoints(t *testing.T) {
+	newContainer := func(name string, networks map[string]*network.EndpointSettings) *Container {
+		return &Container{
+			Name: name,
+			Details: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{},
+				NetworkSettings:   &container.NetworkSettings{Networks: networks},
+			},
+		}
+	}
+
+	containers := []*Container{
+		newContainer("web", map[string]*network.EndpointSettings{
+			"frontend": {NetworkID: "frontend-id", IPAddress: "172.20.0.3"},
+			"backend":  {NetworkID: "backend-id", IPAddress: "172.21.0.3"},
+		}),
+		newContainer("db", map[string]*network.EndpointSettings{
+			// the network has since been renamed
+			"old-backend-name": {NetworkID: "backend-id", IPAddress: "172.21.0.2"},
+		}),
+		newContainer("worker", map[string]*network.EndpointSettings{
+			"frontend": {NetworkID: "frontend-id"},
+		}),
+		// details not loaded yet
+		{Name: "new"},
+	}
+
+	backend := &Network{Name: "backend", Network: network.Inspect{ID: "backend-id"}}
+
+	endpoints := backend.Endpoints(containers)
+	assert.EqualValues(t, []string{"db", "web"}, lo.Map(endpoints, func(endpoint *NetworkEndpoint, _ int) string {
+		return endpoint.Container.Name
+	}))
+	assert.EqualValues(t, []string{"172.21.0.2", "172.21.0.3"}, lo.Map(endpoints, func(endpoint *NetworkEndpoint, _ int) string {
+		return endpoint.Settings.IPAddress
+	}))
+
+	assert.True(t, backend.IsConnected(containers[0]))
+	assert.False(t, backend.IsConnected(containers[2]))
+	assert.False(t, backend.IsConnected(containers[3]))
+
+	assert.EqualValues(t, []string{"backend", "frontend"}, lo.Map(containers[0].NetworkEndpoints(), func(endpoint *NetworkEndpoint, _ int) string {
+		return endpoint.NetworkName
+	}))
+}
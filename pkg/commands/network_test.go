@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNetworkIPAMConfig is a function.
+func TestNetworkIPAMConfig(t *testing.T) {
+	type scenario struct {
+		name       string
+		subnets    []string
+		gateways   []string
+		ipRanges   []string
+		enableIPv6 bool
+		expected   []network.IPAMConfig
+		err        string
+	}
+
+	scenarios := []scenario{
+		{
+			name:     "nothing",
+			expected: []network.IPAMConfig{},
+		},
+		{
+			name:     "subnet with gateway and IP range",
+			subnets:  []string{"172.28.0.0/16"},
+			gateways: []string{"172.28.0.1"},
+			ipRanges: []string{"172.28.5.0/24"},
+			expected: []network.IPAMConfig{{Subnet: "172.28.0.0/16", Gateway: "172.28.0.1", IPRange: "172.28.5.0/24"}},
+		},
+		{
+			name:       "gateways go with the subnets containing them",
+			subnets:    []string{"10.1.0.0/24", "fd00:1::/64"},
+			gateways:   []string{"fd00:1::1", "10.1.0.254"},
+			enableIPv6: true,
+			expected: []network.IPAMConfig{
+				{Subnet: "10.1.0.0/24", Gateway: "10.1.0.254"},
+				{Subnet: "fd00:1::/64", Gateway: "fd00:1::1"},
+			},
+		},
+		{
+			name:    "invalid subnet",
+			subnets: []string{"172.28.0.0"},
+			err:     "invalid subnet 172.28.0.0: expected CIDR notation e.g. 172.28.0.0/16",
+		},
+		{
+			name:    "subnet with host bits set",
+			subnets: []string{"172.28.0.1/16"},
+			err:     "invalid network config:\ninvalid subnet 172.28.0.1/16: it should be 172.28.0.0/16",
+		},
+		{
+			name:    "IPv6 subnet without IPv6",
+			subnets: []string{"fd00:1::/64"},
+			err:     "subnet fd00:1::/64 is an IPv6 subnet, which requires IPv6 to be enabled",
+		},
+		{
+			name:    "overlapping subnets",
+			subnets: []string{"10.0.0.0/8", "10.1.0.0/16"},
+			err:     "subnets 10.0.0.0/8 and 10.1.0.0/16 overlap",
+		},
+		{
+			name:     "gateway outside the subnets",
+			subnets:  []string{"172.28.0.0/16"},
+			gateways: []string{"10.0.0.1"},
+			err:      "gateway 10.0.0.1 isn't in any of the subnets",
+		},
+		{
+			name:     "invalid gateway",
+			subnets:  []string{"172.28.0.0/16"},
+			gateways: []string{"172.28.0.0/16"},
+			err:      "invalid gateway 172.28.0.0/16: expected an IP address",
+		},
+		{
+			name:     "two gateways for one subnet",
+			subnets:  []string{"172.28.0.0/16"},
+			gateways: []string{"172.28.0.1", "172.28.0.2"},
+			err:      "subnet 172.28.0.0/16 can only have one gateway",
+		},
+		{
+			name:     "IP range bigger than its subnet",
+			subnets:  []string{"172.28.0.0/24"},
+			ipRanges: []string{"172.28.0.0/16"},
+			err:      "invalid network config:\ninvalid ip-range 172.28.0.0/16: CIDR block is bigger than its parent subnet 172.28.0.0/24",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := NetworkIPAMConfig(s.subnets, s.gateways, s.ipRanges, s.enableIPv6)
+			if s.err != "" {
+				assert.EqualError(t, err, s.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+}
+
+// TestParseList is a function.
+func TestParseList(t *testing.T) {
+	assert.EqualValues(t, []string{"10.0.0.0/24", "fd00::/64", "10.1.0.0/24"}, ParseList(" 10.0.0.0/24,fd00::/64  10.1.0.0/24, "))
+	assert.Empty(t, ParseList("  "))
+}
+
+// TestNetworkCreateOptions is a function.
+func TestNetworkCreateOptions(t *testing.T) {
+	createOptions, err := networkCreateOptions(NetworkCreateOptions{Name: "app", Driver: "bridge"})
+	assert.NoError(t, err)
+	assert.Nil(t, createOptions.EnableIPv6)
+	assert.Nil(t, createOptions.IPAM)
+
+	createOptions, err = networkCreateOptions(NetworkCreateOptions{Name: "app", Driver: "bridge", Subnets: []string{"fd00::/64"}, EnableIPv6: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, createOptions.EnableIPv6)
+	assert.True(t, *createOptions.EnableIPv6)
+	assert.EqualValues(t, []network.IPAMConfig{{Subnet: "fd00::/64"}}, createOptions.IPAM.Config)
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/samber/lo"
+)
+
+// NetworkTopology describes which containers are on which networks, and so
+// which containers can reach each other, along with the ports the containers
+// publish on the host
+type NetworkTopology struct {
+	// Networks are sorted by name
+	Networks []*TopologyNetwork
+	// PublishedPorts are sorted by container name and then host port
+	PublishedPorts []*PublishedPort
+}
+
+// TopologyNetwork is a network along with the containers on it
+type TopologyNetwork struct {
+	Network   *Network
+	Endpoints []*NetworkEndpoint
+}
+
+// PublishedPort is a container port that's published on the host
+type PublishedPort struct {
+	Container *Container
+	Port      container.Port
+}
+
+// ContainerPeers is a container along with the containers it shares a network
+// with, i.e. the ones it can reach
+type ContainerPeers struct {
+	Container *Container
+	// Peers maps the names of the containers it can reach to the names of the
+	// networks it reaches them through
+	Peers map[string][]string
+}
+
+// BuildNetworkTopology works out which of the given containers are on which of
+// the given networks. If onlyConnected is true we leave out the networks none
+// of the containers are on, e.g. when we're only interested in a project's
+// containers.
+func BuildNetworkTopology(networks []*Network, containers []*Container, onlyConnected bool) *NetworkTopology {
+	topology := &NetworkTopology{}
+
+	for _, network := range networks {
+		endpoints := network.Endpoints(containers)
+		if onlyConnected && len(endpoints) == 0 {
+			continue
+		}
+		topology.Networks = append(topology.Networks, &TopologyNetwork{Network: network, Endpoints: endpoints})
+	}
+	sort.Slice(topology.Networks, func(i, j int) bool {
+		return topology.Networks[i].Network.Name < topology.Networks[j].Network.Name
+	})
+
+	for _, ctr := range containers {
+		for _, port := range ctr.Container.Ports {
+			if port.PublicPort == 0 {
+				continue
+			}
+			topology.PublishedPorts = append(topology.PublishedPorts, &PublishedPort{Container: ctr, Port: port})
+		}
+	}
+	sort.SliceStable(topology.PublishedPorts, func(i, j int) bool {
+		a, b := topology.PublishedPorts[i], topology.PublishedPorts[j]
+		if a.Container.Name != b.Container.Name {
+			return a.Container.Name < b.Container.Name
+		}
+		return a.Port.PublicPort < b.Port.PublicPort
+	})
+
+	return topology
+}
+
+// Peers returns, for each container on at least one network, the containers
+// it shares a network with. They're sorted by container name. Containers on
+// the 'none' network have no networking at all, and those on the 'host' network
+// use the host's, so neither network lets its containers reach each other.
+func (t *NetworkTopology) Peers() []*ContainerPeers {
+	byID := map[string]*ContainerPeers{}
+
+	for _, network := range t.Networks {
+		if lo.Contains([]string{"null", "host"}, network.Network.Network.Driver) {
+			continue
+		}
+
+		for _, endpoint := range network.Endpoints {
+			peers, ok := byID[endpoint.Container.ID]
+			if !ok {
+				peers = &ContainerPeers{Container: endpoint.Container, Peers: map[string][]string{}}
+				byID[endpoint.Container.ID] = peers
+			}
+
+			for _, other := range network.Endpoints {
+				if other.Container.ID == endpoint.Container.ID {
+					continue
+				}
+				peers.Peers[other.Container.Name] = append(peers.Peers[other.Container.Name], network.Network.Name)
+			}
+		}
+	}
+
+	result := lo.Values(byID)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Container.Name < result[j].Container.Name
+	})
+
+	return result
+}
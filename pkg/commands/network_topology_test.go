@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildNetworkTopology is a function.
+func TestBuildNetworkTopology(t *testing.T) {
+	newContainer := func(id string, name string, ports []container.Port, networks map[string]*network.EndpointSettings) *Container {
+		return &Container{
+			ID:        id,
+			Name:      name,
+			Container: container.Summary{Ports: ports},
+			Details: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{},
+				NetworkSettings:   &container.NetworkSettings{Networks: networks},
+			},
+		}
+	}
+
+	web := newContainer("web-id", "web", []container.Port{
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+		{PrivatePort: 9000, Type: "tcp"},
+	}, map[string]*network.EndpointSettings{
+		"frontend": {NetworkID: "frontend-id"},
+		"backend":  {NetworkID: "backend-id"},
+	})
+	db := newContainer("db-id", "db", nil, map[string]*network.EndpointSettings{
+		"backend": {NetworkID: "backend-id"},
+	})
+	// we only know about this one's network from the network itself
+	proxy := &Container{ID: "proxy-id", Name: "proxy"}
+
+	networks := []*Network{
+		{Name: "frontend", Network: network.Inspect{ID: "frontend-id", Containers: map[string]network.EndpointResource{
+			"proxy-id": {Name: "proxy", EndpointID: "ep", IPv4Address: "172.20.0.5/16"},
+		}}},
+		{Name: "backend", Network: network.Inspect{ID: "backend-id"}},
+		{Name: "unused", Network: network.Inspect{ID: "unused-id"}},
+	}
+	containers := []*Container{web, db, proxy}
+
+	networkNames := func(topology *NetworkTopology) []string {
+		return lo.Map(topology.Networks, func(network *TopologyNetwork, _ int) string { return network.Network.Name })
+	}
+
+	assert.EqualValues(t, []string{"backend", "frontend", "unused"}, networkNames(BuildNetworkTopology(networks, containers, false)))
+
+	topology := BuildNetworkTopology(networks, containers, true)
+	assert.EqualValues(t, []string{"backend", "frontend"}, networkNames(topology))
+
+	frontend := topology.Networks[1]
+	assert.EqualValues(t, []string{"proxy", "web"}, lo.Map(frontend.Endpoints, func(endpoint *NetworkEndpoint, _ int) string {
+		return endpoint.Container.Name
+	}))
+	assert.EqualValues(t, "172.20.0.5", frontend.Endpoints[0].Settings.IPAddress)
+	assert.EqualValues(t, 16, frontend.Endpoints[0].Settings.IPPrefixLen)
+
+	assert.EqualValues(t, []uint16{8080, 8443}, lo.Map(topology.PublishedPorts, func(port *PublishedPort, _ int) uint16 {
+		return port.Port.PublicPort
+	}))
+
+	peers := topology.Peers()
+	assert.EqualValues(t, []string{"db", "proxy", "web"}, lo.Map(peers, func(peers *ContainerPeers, _ int) string {
+		return peers.Container.Name
+	}))
+	assert.EqualValues(t, map[string][]string{"web": {"backend"}}, peers[0].Peers)
+	assert.EqualValues(t, map[string][]string{"web": {"frontend"}}, peers[1].Peers)
+	assert.EqualValues(t, map[string][]string{"db": {"backend"}, "proxy": {"frontend"}}, peers[2].Peers)
+
+	// containers without networking, or using the host's, can't reach each other
+	// through those networks
+	isolated := newContainer("isolated-id", "isolated", nil, map[string]*network.EndpointSettings{
+		"none": {NetworkID: "none-id"},
+	})
+	sandboxed := newContainer("sandboxed-id", "sandboxed", nil, map[string]*network.EndpointSettings{
+		"none": {NetworkID: "none-id"},
+	})
+	hostA := newContainer("host-a-id", "host-a", nil, map[string]*network.EndpointSettings{
+		"host": {NetworkID: "host-id"},
+	})
+	hostB := newContainer("host-b-id", "host-b", nil, map[string]*network.EndpointSettings{
+		"host": {NetworkID: "host-id"},
+	})
+	networks = append(networks,
+		&Network{Name: "none", Network: network.Inspect{ID: "none-id", Driver: "null"}},
+		&Network{Name: "host", Network: network.Inspect{ID: "host-id", Driver: "host"}},
+	)
+	topology = BuildNetworkTopology(networks, []*Container{web, db, proxy, isolated, sandboxed, hostA, hostB}, true)
+	assert.EqualValues(t, []string{"backend", "frontend", "host", "none"}, networkNames(topology))
+	assert.EqualValues(t, []string{"db", "proxy", "web"}, lo.Map(topology.Peers(), func(peers *ContainerPeers, _ int) string {
+		return peers.Container.Name
+	}))
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/samber/lo"
+)
+
+// PruneOptions narrows down which containers, networks or volumes a prune
+// removes. The zero value prunes what docker would by default.
+type PruneOptions struct {
+	// Until, if not empty, only prunes things created before the given time:
+	// either a duration like '24h', relative to now, or a timestamp. Docker
+	// doesn't support this for volumes.
+	Until string
+	// Labels only prunes things with all of the given labels, each in the form
+	// 'key' or 'key=value'. Prefixing a label with '!' instead only prunes
+	// things without it.
+	Labels []string
+	// AnonymousOnly only prunes anonymous volumes (the ones docker creates for
+	// a container's VOLUMEs) rather than named ones too. Only for volumes.
+	AnonymousOnly bool
+}
+
+// the label docker gives anonymous volumes, which it goes by when pruning
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// the networks docker creates itself, which it never prunes
+var predefinedNetworks = []string{"bridge", "host", "none", "ingress", "docker_gwbridge"}
+
+func (o PruneOptions) filterArgs() filters.Args {
+	args := filters.NewArgs()
+	if o.Until != "" {
+		args.Add("until", o.Until)
+	}
+	addLabelFilters(args, o.Labels)
+	return args
+}
+
+func (o PruneOptions) volumeFilterArgs() filters.Args {
+	args := filters.NewArgs()
+	// docker only prunes anonymous volumes unless we ask for all of them
+	if !o.AnonymousOnly {
+		args.Add("all", "true")
+	}
+	addLabelFilters(args, o.Labels)
+	return args
+}
+
+// addLabelFilters adds the filters for labels in the form 'key', 'key=value',
+// '!key' or '!key=value' to args
+func addLabelFilters(args filters.Args, labels []string) {
+	for _, label := range labels {
+		if negated, ok := strings.CutPrefix(label, "!"); ok {
+			args.Add("label!", negated)
+		} else {
+			args.Add("label", label)
+		}
+	}
+}
+
+// matchesLabelFilters tells us whether something with the given labels
+// matches all the label filters, as docker would see it
+func matchesLabelFilters(labels map[string]string, labelFilters []string) bool {
+	hasLabel := func(label string) bool {
+		key, value, hasValue := strings.Cut(label, "=")
+		actual, ok := labels[key]
+		return ok && (!hasValue || actual == value)
+	}
+
+	for _, label := range labelFilters {
+		if negated, ok := strings.CutPrefix(label, "!"); ok {
+			if hasLabel(negated) {
+				return false
+			}
+		} else if !hasLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o PruneOptions) until(now time.Time) (time.Time, error) {
+	if o.Until == "" {
+		return time.Time{}, nil
+	}
+	return ParseUntil(o.Until, now)
+}
+
+// ContainersToPrune works out which of the given containers PruneContainers
+// would remove with the given options: the ones that aren't running
+func ContainersToPrune(containers []*Container, options PruneOptions, now time.Time) ([]*Container, error) {
+	until, err := options.until(now)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(containers, func(ctr *Container, _ int) bool {
+		switch ctr.Container.State {
+		case "created", "exited", "dead":
+		default:
+			return false
+		}
+
+		if !until.IsZero() && !time.Unix(ctr.Container.Created, 0).Before(until) {
+			return false
+		}
+
+		return matchesLabelFilters(ctr.Container.Labels, options.Labels)
+	}), nil
+}
+
+// NetworksToPrune works out which of the given networks PruneNetworks would
+// remove with the given options: the ones we created with no containers on
+// them
+func NetworksToPrune(networks []*Network, containers []*Container, options PruneOptions, now time.Time) ([]*Network, error) {
+	until, err := options.until(now)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(networks, func(network *Network, _ int) bool {
+		if lo.Contains(predefinedNetworks, network.Name) || network.Network.Ingress {
+			return false
+		}
+
+		// stopped containers keep their networks, and we may not have loaded
+		// their details, so we also go by the container list
+		if len(network.Endpoints(containers)) > 0 || lo.SomeBy(containers, func(ctr *Container) bool {
+			return ctr.Container.NetworkSettings != nil && ctr.Container.NetworkSettings.Networks[network.Name] != nil
+		}) {
+			return false
+		}
+
+		if !until.IsZero() && !network.Network.Created.Before(until) {
+			return false
+		}
+
+		return matchesLabelFilters(network.Network.Labels, options.Labels)
+	}), nil
+}
+
+// VolumesToPrune works out which of the given volumes PruneVolumes would
+// remove with the given options: the ones no container mounts
+func VolumesToPrune(volumes []*Volume, containers []*Container, options PruneOptions) []*Volume {
+	return lo.Filter(volumes, func(volume *Volume, _ int) bool {
+		if len(volume.Mounts(containers)) > 0 {
+			return false
+		}
+
+		if _, ok := volume.Volume.Labels[anonymousVolumeLabel]; options.AnonymousOnly && !ok {
+			return false
+		}
+
+		return matchesLabelFilters(volume.Volume.Labels, options.Labels)
+	})
+}
+
+// PruneContainers prunes the stopped containers matching the given options,
+// returning what was removed
+func (c *DockerCommand) PruneContainers(options PruneOptions) (container.PruneReport, error) {
+	return c.Client.ContainersPrune(context.Background(), options.filterArgs())
+}
+
+// PruneNetworks prunes the unused networks matching the given options,
+// returning what was removed
+func (c *DockerCommand) PruneNetworks(options PruneOptions) (network.PruneReport, error) {
+	return c.Client.NetworksPrune(context.Background(), options.filterArgs())
+}
+
+// PruneVolumes prunes the unused volumes matching the given options, returning
+// what was removed. Docker doesn't support the 'until' option for volumes.
+func (c *DockerCommand) PruneVolumes(options PruneOptions) (volume.PruneReport, error) {
+	return c.Client.VolumesPrune(context.Background(), options.volumeFilterArgs())
+}
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestContainersToPrune is a function.
+func TestContainersToPrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour).Unix()
+	recent := now.Add(-time.Hour).Unix()
+
+	newContainer := func(name string, state string, created int64, labels map[string]string) *Container {
+		return &Container{Name: name, Container: container.Summary{State: state, Created: created, Labels: labels}}
+	}
+
+	exitedOld := newContainer("exited-old", "exited", old, map[string]string{"env": "dev"})
+	exitedRecent := newContainer("exited-recent", "exited", recent, map[string]string{"env": "prod", "keep": ""})
+	created := newContainer("created", "created", old, nil)
+	dead := newContainer("dead", "dead", recent, nil)
+
+	containers := []*Container{
+		exitedOld,
+		exitedRecent,
+		created,
+		dead,
+		newContainer("running", "running", old, nil),
+		newContainer("paused", "paused", old, nil),
+		newContainer("restarting", "restarting", old, nil),
+	}
+
+	type scenario struct {
+		name     string
+		options  PruneOptions
+		expected []*Container
+	}
+
+	scenarios := []scenario{
+		{"stopped", PruneOptions{}, []*Container{exitedOld, exitedRecent, created, dead}},
+		{"until", PruneOptions{Until: "24h"}, []*Container{exitedOld, created}},
+		{"label", PruneOptions{Labels: []string{"env"}}, []*Container{exitedOld, exitedRecent}},
+		{"label value", PruneOptions{Labels: []string{"env=prod"}}, []*Container{exitedRecent}},
+		{"negated label", PruneOptions{Labels: []string{"!keep"}}, []*Container{exitedOld, created, dead}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := ContainersToPrune(containers, s.options, now)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+
+	_, err := ContainersToPrune(containers, PruneOptions{Until: "yesterday"}, now)
+	assert.Error(t, err)
+}
+
+// TestNetworksToPrune is a function.
+func TestNetworksToPrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newNetwork := func(name string, created time.Time, labels map[string]string) *Network {
+		return &Network{Name: name, Network: network.Inspect{ID: name + "-id", Name: name, Created: created, Labels: labels}}
+	}
+
+	unusedOld := newNetwork("unused-old", now.Add(-48*time.Hour), map[string]string{"env": "dev"})
+	unusedRecent := newNetwork("unused-recent", now.Add(-time.Hour), nil)
+	usedByDetails := newNetwork("used-by-details", now.Add(-48*time.Hour), nil)
+	usedByList := newNetwork("used-by-list", now.Add(-48*time.Hour), nil)
+
+	networks := []*Network{
+		unusedOld,
+		unusedRecent,
+		usedByDetails,
+		usedByList,
+		newNetwork("bridge", now.Add(-48*time.Hour), nil),
+		newNetwork("host", now.Add(-48*time.Hour), nil),
+		newNetwork("none", now.Add(-48*time.Hour), nil),
+	}
+	containers := []*Container{
+		{
+			Name: "web",
+			Details: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{},
+				NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					"used-by-details": {NetworkID: "used-by-details-id"},
+				}},
+			},
+		},
+		// a stopped container whose details we haven't loaded
+		{Name: "db", Container: container.Summary{NetworkSettings: &container.NetworkSettingsSummary{
+			Networks: map[string]*network.EndpointSettings{"used-by-list": {}},
+		}}},
+	}
+
+	type scenario struct {
+		name     string
+		options  PruneOptions
+		expected []*Network
+	}
+
+	scenarios := []scenario{
+		{"unused", PruneOptions{}, []*Network{unusedOld, unusedRecent}},
+		{"until", PruneOptions{Until: "24h"}, []*Network{unusedOld}},
+		{"label", PruneOptions{Labels: []string{"env=dev"}}, []*Network{unusedOld}},
+		{"negated label", PruneOptions{Labels: []string{"!env"}}, []*Network{unusedRecent}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := NetworksToPrune(networks, containers, s.options, now)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, actual)
+		})
+	}
+}
+
+// TestVolumesToPrune is a function.
+func TestVolumesToPrune(t *testing.T) {
+	newVolume := func(name string, labels map[string]string) *Volume {
+		return &Volume{Name: name, Volume: &volume.Volume{Name: name, Labels: labels}}
+	}
+
+	anonymous := newVolume("0123456789abcdef", map[string]string{anonymousVolumeLabel: ""})
+	named := newVolume("data", map[string]string{"env": "dev"})
+	mounted := newVolume("db", nil)
+
+	volumes := []*Volume{anonymous, named, mounted}
+	containers := []*Container{
+		{Name: "postgres", Container: container.Summary{Mounts: []container.MountPoint{{Type: mount.TypeVolume, Name: "db"}}}},
+	}
+
+	type scenario struct {
+		name     string
+		options  PruneOptions
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{"all unused", PruneOptions{}, []string{"0123456789abcdef", "data"}},
+		{"anonymous only", PruneOptions{AnonymousOnly: true}, []string{"0123456789abcdef"}},
+		{"label", PruneOptions{Labels: []string{"env=dev"}}, []string{"data"}},
+		{"anonymous with label", PruneOptions{AnonymousOnly: true, Labels: []string{"env"}}, []string{}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			actual := VolumesToPrune(volumes, containers, s.options)
+			assert.EqualValues(t, s.expected, lo.Map(actual, func(volume *Volume, _ int) string { return volume.Name }))
+		})
+	}
+}
+
+// TestPruneFilterArgs is a function.
+func TestPruneFilterArgs(t *testing.T) {
+	options := PruneOptions{Until: "24h", Labels: []string{"env=dev", "!keep"}}
+
+	args := options.filterArgs()
+	assert.EqualValues(t, []string{"24h"}, args.Get("until"))
+	assert.EqualValues(t, []string{"env=dev"}, args.Get("label"))
+	assert.EqualValues(t, []string{"keep"}, args.Get("label!"))
+	assert.Equal(t, 0, PruneOptions{}.filterArgs().Len())
+
+	// docker doesn't support 'until' for volumes
+	args = options.volumeFilterArgs()
+	assert.False(t, args.Contains("until"))
+	assert.EqualValues(t, []string{"true"}, args.Get("all"))
+	assert.EqualValues(t, []string{"env=dev"}, args.Get("label"))
+
+	assert.Equal(t, 0, PruneOptions{AnonymousOnly: true}.volumeFilterArgs().Len())
+}
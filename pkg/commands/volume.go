@@ -2,10 +2,15 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
@@ -30,7 +35,19 @@ func (c *DockerCommand) RefreshVolumes() ([]*Volume, error) {
 
 	ownVolumes := make([]*Volume, len(volumes))
 
+	// docker only includes a volume's size and reference count when we ask for
+	// its disk usage, so we ask for that too. We can do without the sizes if
+	// docker can't work them out.
+	usage, err := c.volumeUsage()
+	if err != nil {
+		c.Log.Error(err)
+	}
+
 	for i, vol := range volumes {
+		if vol.UsageData == nil {
+			vol.UsageData = usage[vol.Name]
+		}
+
 		ownVolumes[i] = &Volume{
 			Name:          vol.Name,
 			Volume:        vol,
@@ -44,10 +61,107 @@ func (c *DockerCommand) RefreshVolumes() ([]*Volume, error) {
 	return ownVolumes, nil
 }
 
-// PruneVolumes prunes volumes
-func (c *DockerCommand) PruneVolumes() error {
-	_, err := c.Client.VolumesPrune(context.Background(), filters.Args{})
-	return err
+// VolumeCreateOptions are the options for creating a volume
+type VolumeCreateOptions struct {
+	// Name, if empty, is generated by docker
+	Name       string
+	Driver     string
+	DriverOpts map[string]string
+	Labels     map[string]string
+}
+
+// CreateVolume creates a volume, returning its name
+func (c *DockerCommand) CreateVolume(options VolumeCreateOptions) (string, error) {
+	c.Log.Warn(fmt.Sprintf("creating volume %s", options.Name))
+
+	vol, err := c.Client.VolumeCreate(context.Background(), volume.CreateOptions{
+		Name:       options.Name,
+		Driver:     options.Driver,
+		DriverOpts: options.DriverOpts,
+		Labels:     options.Labels,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return vol.Name, nil
+}
+
+// CloneOptions returns the options for creating a new volume like this one.
+// We leave out docker compose's labels, or compose would take the clone for
+// one of its project's volumes.
+func (v *Volume) CloneOptions(name string) VolumeCreateOptions {
+	return VolumeCreateOptions{
+		Name:       name,
+		Driver:     v.Volume.Driver,
+		DriverOpts: v.Volume.Options,
+		Labels: lo.OmitBy(v.Volume.Labels, func(key string, _ string) bool {
+			return strings.HasPrefix(key, "com.docker.compose.")
+		}),
+	}
+}
+
+// ParseKeyValues parses a ';'-separated list of key=value pairs e.g.
+// `env=prod; team=web`, as used for labels and driver options. A key without
+// a value gets an empty value, like with `docker volume create --label`.
+func ParseKeyValues(str string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range strings.Split(str, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid key/value pair '%s'. Expected key=value", pair)
+		}
+
+		values[key] = strings.TrimSpace(value)
+	}
+
+	return values, nil
+}
+
+// VolumeMount is a container's mount of a volume
+type VolumeMount struct {
+	Container *Container
+	Mount     container.MountPoint
+}
+
+// Mounts returns the given containers' mounts of the volume, sorted by
+// container name. We go by the containers' details where they're loaded, since
+// those are the most up to date.
+func (v *Volume) Mounts(containers []*Container) []*VolumeMount {
+	mounts := []*VolumeMount{}
+	for _, ctr := range containers {
+		mountPoints := ctr.Container.Mounts
+		if ctr.DetailsLoaded() {
+			mountPoints = ctr.Details.Mounts
+		}
+
+		for _, mountPoint := range mountPoints {
+			if mountPoint.Type == mount.TypeVolume && mountPoint.Name == v.Name {
+				mounts = append(mounts, &VolumeMount{Container: ctr, Mount: mountPoint})
+			}
+		}
+	}
+
+	sort.SliceStable(mounts, func(i, j int) bool {
+		return mounts[i].Container.Name < mounts[j].Container.Name
+	})
+
+	return mounts
+}
+
+// VolumeMountContainers returns the containers the mounts belong to. A
+// container can mount the same volume more than once, but we only return it
+// once.
+func VolumeMountContainers(mounts []*VolumeMount) []*Container {
+	return lo.Uniq(lo.Map(mounts, func(mount *VolumeMount, _ int) *Container {
+		return mount.Container
+	}))
 }
 
 // Remove removes the volume
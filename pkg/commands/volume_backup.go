@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// BackupVolume writes a gzipped tar archive of the volume's contents to the
+// given path on the host. The archive's paths are relative to the root of the
+// volume, as with `tar -C <volume> -czf <archive> .`, so it can be restored
+// into any volume. onProgress is called with the number of bytes read from
+// the volume so far.
+func (c *DockerCommand) BackupVolume(ctx context.Context, volumeName string, archivePath string, onProgress func(int64)) (err error) {
+	c.Log.Warn(fmt.Sprintf("backing up volume %s to %s", volumeName, archivePath))
+
+	helper, err := c.NewVolumeHelper(ctx, volumeName, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := helper.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	reader, _, err := c.Client.CopyFromContainer(ctx, helper.ID, VolumeHelperMountPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	// we don't want to leave half an archive lying around
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(archivePath)
+		}
+	}()
+
+	gz := gzip.NewWriter(file)
+	if err := rebaseArchive(newProgressReader(reader, onProgress), gz, path.Base(VolumeHelperMountPath), "."); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// RestoreVolume extracts a tar archive on the host (which may be compressed
+// with gzip, bzip2 or xz) into the volume. If replace is true we first remove
+// everything already in the volume, so that it ends up with just what's in the
+// archive. Otherwise files already in the volume are kept unless the archive
+// has files of the same name. onProgress is called with the number of bytes
+// of the archive sent so far.
+func (c *DockerCommand) RestoreVolume(ctx context.Context, volumeName string, archivePath string, replace bool, onProgress func(int64)) (err error) {
+	c.Log.Warn(fmt.Sprintf("restoring volume %s from %s", volumeName, archivePath))
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	helper, err := c.NewVolumeHelper(ctx, volumeName, false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := helper.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if replace {
+		if err := helper.clear(ctx); err != nil {
+			return err
+		}
+	}
+
+	// docker decompresses the archive for us
+	return c.Client.CopyToContainer(ctx, helper.ID, VolumeHelperMountPath, newProgressReader(file, onProgress), container.CopyToContainerOptions{})
+}
+
+// CopyVolume copies the contents of one volume into another. As with
+// RestoreVolume, if replace is true we first remove everything already in the
+// destination volume, and otherwise its files are kept unless the source has
+// files of the same name. onProgress is called with the number of bytes copied
+// so far.
+func (c *DockerCommand) CopyVolume(ctx context.Context, srcVolumeName string, dstVolumeName string, replace bool, onProgress func(int64)) (err error) {
+	c.Log.Warn(fmt.Sprintf("copying volume %s to %s", srcVolumeName, dstVolumeName))
+
+	src, err := c.NewVolumeHelper(ctx, srcVolumeName, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := src.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	dst, err := c.NewVolumeHelper(ctx, dstVolumeName, false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if replace {
+		if err := dst.clear(ctx); err != nil {
+			return err
+		}
+	}
+
+	reader, _, err := c.Client.CopyFromContainer(ctx, src.ID, VolumeHelperMountPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// the archive's paths start with the mount path's base name, so extracting
+	// it at the root of the destination helper puts its files in the volume
+	return c.Client.CopyToContainer(ctx, dst.ID, path.Dir(VolumeHelperMountPath), newProgressReader(reader, onProgress), container.CopyToContainerOptions{})
+}
+
+// VolumeIsEmpty tells us whether the volume has nothing in it
+func (c *DockerCommand) VolumeIsEmpty(ctx context.Context, volumeName string) (empty bool, err error) {
+	helper, err := c.NewVolumeHelper(ctx, volumeName, true)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if closeErr := helper.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	reader, _, err := c.Client.CopyFromContainer(ctx, helper.ID, VolumeHelperMountPath)
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	return archiveIsEmpty(reader)
+}
+
+// archiveIsEmpty tells us whether a tar stream of a copied directory has
+// nothing but the directory itself. We only read as far as we need to.
+func archiveIsEmpty(r io.Reader) (bool, error) {
+	tr := tar.NewReader(r)
+	for i := 0; i < 2; i++ {
+		if _, err := tr.Next(); err == io.EOF {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
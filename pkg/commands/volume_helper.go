@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// VolumeHelperMountPath is where helper containers have their volume mounted
+const VolumeHelperMountPath = "/volume"
+
+// a label we put on helper containers so that we can leave them out of the
+// containers panel, and remove them the next time we start if we fail to
+// remove one, e.g. because we crashed. Its value identifies the instance of
+// lazydocker-ng that owns the helper.
+const volumeHelperLabel = "lazydocker-ng.volume-helper"
+
+// volumeHelperOwner identifies this instance of lazydocker-ng by host, pid and
+// start time. The start time tells us apart from an earlier instance that had
+// the same pid, e.g. when running as pid 1 in a restarted container.
+var volumeHelperOwner = newVolumeHelperOwner(time.Now())
+
+type helperOwner struct {
+	host      string
+	pid       int
+	startedAt int64
+}
+
+func newVolumeHelperOwner(startedAt time.Time) helperOwner {
+	host, _ := os.Hostname()
+	return helperOwner{host: host, pid: os.Getpid(), startedAt: startedAt.UnixNano()}
+}
+
+func (o helperOwner) String() string {
+	return fmt.Sprintf("%d:%d:%s", o.pid, o.startedAt, o.host)
+}
+
+func parseHelperOwner(value string) (helperOwner, bool) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return helperOwner{}, false
+	}
+	pid, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return helperOwner{}, false
+	}
+	startedAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return helperOwner{}, false
+	}
+	return helperOwner{host: parts[2], pid: pid, startedAt: startedAt}, true
+}
+
+// gone tells whether the instance that owns a helper has exited. We can only
+// tell for instances on our own host, so we assume any others are still
+// running.
+func (o helperOwner) gone(us helperOwner) bool {
+	if o.host != us.host {
+		return false
+	}
+	if o.pid == us.pid {
+		return o.startedAt != us.startedAt
+	}
+	return !processExists(o.pid)
+}
+
+// IsVolumeHelper tells whether the container is one of our volume helpers
+func IsVolumeHelper(labels map[string]string) bool {
+	_, ok := labels[volumeHelperLabel]
+	return ok
+}
+
+// VolumeHelper is a short-lived container with a volume mounted in it. Docker
+// has no API for looking inside a volume, but it does for copying files out of
+// a container, even one that was never started. For anything else, like
+// listing a directory without copying everything in it, we start the helper
+// and run commands in it.
+type VolumeHelper struct {
+	*Container
+
+	// the number of operations using the helper. We don't remove the helper
+	// until they're done.
+	users   int
+	closed  bool
+	started bool
+	mutex   sync.Mutex
+}
+
+// NewVolumeHelper creates a helper container with the volume mounted at
+// VolumeHelperMountPath, pulling the configured helper image if we don't have
+// it. Unless we're going to write to the volume, it should be mounted
+// read-only. The caller must close the helper once they're done with it.
+func (c *DockerCommand) NewVolumeHelper(ctx context.Context, volumeName string, readOnly bool) (*VolumeHelper, error) {
+	helperImage := c.Config.UserConfig.VolumeHelper.Image
+
+	if _, err := c.Client.ImageInspect(ctx, helperImage); err != nil {
+		if !cerrdefs.IsNotFound(err) {
+			return nil, err
+		}
+		if err := c.PullImage(ctx, helperImage, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// if ctx were cancelled mid-request, docker might create the container
+	// without us knowing its ID to remove it, so we see the request through
+	created, err := c.Client.ContainerCreate(context.WithoutCancel(ctx),
+		&container.Config{
+			Image: helperImage,
+			// the helper does nothing but stay up for us to run commands in it
+			Cmd:    []string{"sh", "-c", "while sleep 3600; do :; done"},
+			Labels: map[string]string{volumeHelperLabel: volumeHelperOwner.String()},
+		},
+		&container.HostConfig{
+			Mounts: []mount.Mount{{
+				Type:     mount.TypeVolume,
+				Source:   volumeName,
+				Target:   VolumeHelperMountPath,
+				ReadOnly: readOnly,
+			}},
+			NetworkMode: "none",
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Warn(fmt.Sprintf("created helper container %s for volume %s", created.ID, volumeName))
+
+	helper := &VolumeHelper{
+		Container: &Container{
+			ID:            created.ID,
+			Name:          created.ID[:12],
+			Client:        c.Client,
+			OSCommand:     c.OSCommand,
+			Log:           c.Log,
+			DockerCommand: c,
+			Tr:            c.Tr,
+		},
+	}
+	if err := ctx.Err(); err != nil {
+		if removeErr := helper.Close(); removeErr != nil {
+			c.Log.Error(removeErr)
+		}
+		return nil, err
+	}
+
+	return helper, nil
+}
+
+// RemoveVolumeHelpers removes helper containers whose owner has exited without
+// removing them. Helpers belonging to other running instances are left alone.
+func (c *DockerCommand) RemoveVolumeHelpers(ctx context.Context) error {
+	helpers, err := c.Client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", volumeHelperLabel)),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, helper := range helpers {
+		owner, ok := parseHelperOwner(helper.Labels[volumeHelperLabel])
+		if !ok || !owner.gone(volumeHelperOwner) {
+			continue
+		}
+
+		c.Log.Warn(fmt.Sprintf("removing leftover helper container %s", helper.ID))
+		if err := c.Client.ContainerRemove(ctx, helper.ID, container.RemoveOptions{Force: true}); err != nil && !cerrdefs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDir lists a directory in the volume. We start the helper to run a
+// listing command in it, as copying the directory out to list it would mean
+// copying everything beneath it, which for a database's volume could be
+// gigabytes.
+func (h *VolumeHelper) ListDir(ctx context.Context, dir string) ([]ArchiveEntry, error) {
+	if err := h.start(ctx); err != nil {
+		return nil, err
+	}
+
+	return h.execListDir(ctx, dir)
+}
+
+// clear removes everything in the volume, which must be mounted writable
+func (h *VolumeHelper) clear(ctx context.Context) error {
+	if err := h.start(ctx); err != nil {
+		return err
+	}
+
+	// between them the globs match every name but '.' and '..'. A glob that
+	// matches nothing is passed as is, which rm -f ignores.
+	_, err := h.exec(ctx, []string{"sh", "-c", `rm -rf -- "$1"/* "$1"/.[!.]* "$1"/..?*`, "sh", VolumeHelperMountPath})
+	return err
+}
+
+// start starts the helper if we haven't yet
+func (h *VolumeHelper) start(ctx context.Context) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.started {
+		return nil
+	}
+	if err := h.Client.ContainerStart(ctx, h.ID, container.StartOptions{}); err != nil {
+		return err
+	}
+	h.started = true
+	return nil
+}
+
+// Use marks the start of an operation on the helper, so that closing the
+// helper doesn't remove it from under the operation. The returned function
+// marks the operation's end.
+func (h *VolumeHelper) Use() func() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.users++
+	return func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+
+		h.users--
+		if h.closed && h.users == 0 {
+			if err := h.remove(); err != nil {
+				h.Log.Error(err)
+			}
+		}
+	}
+}
+
+// Close removes the helper container, or if something's still using it,
+// has it removed once that's done
+func (h *VolumeHelper) Close() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.closed = true
+	if h.users > 0 {
+		return nil
+	}
+	return h.remove()
+}
+
+func (h *VolumeHelper) remove() error {
+	h.Log.Warn(fmt.Sprintf("removing helper container %s", h.ID))
+	return h.Client.ContainerRemove(context.Background(), h.ID, container.RemoveOptions{Force: true})
+}
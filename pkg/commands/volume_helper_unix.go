@@ -0,0 +1,21 @@
+//go:build !windows
+
+package commands
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// processExists tells whether a process with the given pid is running
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// signal 0 checks for the process without sending it anything. EPERM means
+	// it exists but belongs to someone else.
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
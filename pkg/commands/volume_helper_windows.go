@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"os"
+)
+
+// processExists tells whether a process with the given pid is running. On
+// Windows, finding a process opens a handle to it, which fails if it's gone.
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
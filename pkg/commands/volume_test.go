@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseKeyValues is a function.
+func TestParseKeyValues(t *testing.T) {
+	type scenario struct {
+		str      string
+		expected map[string]string
+		hasError bool
+	}
+
+	scenarios := []scenario{
+		{"", map[string]string{}, false},
+		{"env=prod; team = web ;", map[string]string{"env": "prod", "team": "web"}, false},
+		{"type=nfs; o=addr=10.0.0.1,rw; device=:/exports", map[string]string{"type": "nfs", "o": "addr=10.0.0.1,rw", "device": ":/exports"}, false},
+		{"backup", map[string]string{"backup": ""}, false},
+		{"=prod", nil, true},
+		{"my label=prod", nil, true},
+	}
+
+	for _, s := range scenarios {
+		actual, err := ParseKeyValues(s.str)
+		if s.hasError {
+			assert.Error(t, err, s.str)
+			continue
+		}
+		assert.NoError(t, err, s.str)
+		assert.EqualValues(t, s.expected, actual, s.str)
+	}
+}
+
+// TestArchiveIsEmpty is a function.
+func TestArchiveIsEmpty(t *testing.T) {
+	archive := func(names ...string) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, name := range names {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeDir, Mode: 0o755}))
+		}
+		assert.NoError(t, tw.Close())
+		return buf
+	}
+
+	empty, err := archiveIsEmpty(archive("volume/"))
+	assert.NoError(t, err)
+	assert.True(t, empty)
+
+	empty, err = archiveIsEmpty(archive("volume/", "volume/data/"))
+	assert.NoError(t, err)
+	assert.False(t, empty)
+
+	_, err = archiveIsEmpty(bytes.NewBufferString("not a tar stream, but long enough that tar tries to read a header from it"))
+	assert.Error(t, err)
+}
+
+// TestVolumeMounts is a function.
+func TestVolumeMounts(t *testing.T) {
+	dataMount := container.MountPoint{Type: mount.TypeVolume, Name: "data", Destination: "/var/lib/data", RW: true}
+
+	containers := []*Container{
+		{
+			Name: "web",
+			Details: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{},
+				Mounts: []container.MountPoint{
+					{Type: mount.TypeVolume, Name: "data", Destination: "/srv/data", RW: false},
+					{Type: mount.TypeBind, Source: "/data", Destination: "/data", RW: true},
+				},
+			},
+		},
+		// details not loaded yet, so we go by the container list
+		{Name: "db", Container: container.Summary{Mounts: []container.MountPoint{dataMount}}},
+		{Name: "cache", Container: container.Summary{Mounts: []container.MountPoint{{Type: mount.TypeVolume, Name: "other"}}}},
+		// a bind mount of a directory with the same name isn't the volume
+		{Name: "backup", Container: container.Summary{Mounts: []container.MountPoint{{Type: mount.TypeBind, Name: "data"}}}},
+	}
+
+	mounts := (&Volume{Name: "data"}).Mounts(containers)
+	assert.EqualValues(t, []string{"db", "web"}, lo.Map(mounts, func(mount *VolumeMount, _ int) string {
+		return mount.Container.Name
+	}))
+	assert.EqualValues(t, "/var/lib/data", mounts[0].Mount.Destination)
+	assert.True(t, mounts[0].Mount.RW)
+	assert.EqualValues(t, "/srv/data", mounts[1].Mount.Destination)
+	assert.False(t, mounts[1].Mount.RW)
+}
+
+// TestVolumeCloneOptions is a function.
+func TestVolumeCloneOptions(t *testing.T) {
+	vol := &Volume{
+		Name: "shop_db",
+		Volume: &volume.Volume{
+			Name:    "shop_db",
+			Driver:  "local",
+			Options: map[string]string{"type": "nfs", "o": "addr=10.0.0.1,rw", "device": ":/exports/db"},
+			Labels: map[string]string{
+				"com.docker.compose.project": "shop",
+				"com.docker.compose.volume":  "db",
+				"com.docker.compose.version": "2.24.0",
+				"team":                       "web",
+			},
+		},
+	}
+
+	assert.EqualValues(t, VolumeCreateOptions{
+		Name:       "shop_db-clone",
+		Driver:     "local",
+		DriverOpts: map[string]string{"type": "nfs", "o": "addr=10.0.0.1,rw", "device": ":/exports/db"},
+		Labels:     map[string]string{"team": "web"},
+	}, vol.CloneOptions("shop_db-clone"))
+}
+
+// TestHelperOwnerGone is a function.
+func TestHelperOwnerGone(t *testing.T) {
+	us := helperOwner{host: "laptop", pid: 1, startedAt: 200}
+
+	type scenario struct {
+		name     string
+		label    string
+		valid    bool
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"ourselves", us.String(), true, false},
+		{"an earlier instance with our pid", helperOwner{host: "laptop", pid: 1, startedAt: 100}.String(), true, true},
+		{"an instance on another host", helperOwner{host: "server:2375", pid: 1, startedAt: 100}.String(), true, false},
+		{"a legacy label", "my-volume", false, false},
+	}
+
+	for _, s := range scenarios {
+		owner, ok := parseHelperOwner(s.label)
+		assert.Equal(t, s.valid, ok, s.name)
+		if ok {
+			assert.Equal(t, s.expected, owner.gone(us), s.name)
+		}
+	}
+}
@@ -60,6 +60,17 @@ type UserConfig struct {
 	// Replacements determines how we render an item's info
 	Replacements Replacements `yaml:"replacements,omitempty"`
 
+	// Attach determines how we behave when attaching to a container
+	Attach AttachConfig `yaml:"attach,omitempty"`
+
+	// UpdateCheck determines whether and how often we ask registries if there
+	// are newer versions of the images running containers use
+	UpdateCheck UpdateCheckConfig `yaml:"updateCheck,omitempty"`
+
+	// VolumeHelper determines the short-lived containers we use to look inside
+	// volumes
+	VolumeHelper VolumeHelperConfig `yaml:"volumeHelper,omitempty"`
+
 	// For demo purposes: any list item with one of these strings as a substring
 	// will be filtered out and not displayed.
 	// Not documented because it's subject to change
@@ -216,6 +227,12 @@ type CommandTemplatesConfig struct {
 
 	// List of shells that, in given order, will be tried when attaching to a container.
 	PreferredExecShells []string `yaml:"preferedExecShell,omitempty"`
+
+	// ScanImage is the command for scanning an image for vulnerabilities, shown
+	// in the image's vulnerabilities tab. It must print a trivy or grype JSON
+	// report to stdout, e.g. `grype -o json docker:{{ .Image.ID }}`. Set it to
+	// an empty string to disable scanning.
+	ScanImage string `yaml:"scanImage,omitempty"`
 }
 
 // OSConfig contains config on the level of the os
@@ -336,6 +353,42 @@ type CustomCommand struct {
 	InternalFunction func() error `yaml:"-"`
 }
 
+// AttachConfig determines how we behave when attaching to a container
+type AttachConfig struct {
+	// DetachKeys is the key sequence for detaching from a container without
+	// stopping it. It uses the same format as docker's --detach-keys flag, e.g.
+	// "ctrl-p,ctrl-q"
+	DetachKeys string `yaml:"detachKeys,omitempty"`
+}
+
+// UpdateCheckConfig determines how we check for newer versions of images
+type UpdateCheckConfig struct {
+	// Enabled turns on checking for updates. It's off by default because every
+	// check is a request to the image's registry, and registries like docker
+	// hub limit how many requests you can make.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// Interval is how long we wait before checking an image again. Defaults to
+	// "6h"
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// RequestDelay is how long we wait between requests to registries.
+	// Defaults to "2s"
+	RequestDelay time.Duration `yaml:"requestDelay,omitempty"`
+}
+
+// VolumeHelperConfig determines the short-lived containers we use to look
+// inside volumes. Docker can't show us what's in a volume directly, so we
+// mount it in a container that idles while we run commands in it and copy
+// files out of it, and remove the container once we're done.
+type VolumeHelperConfig struct {
+	// Image is the image we create helper containers from. It should be small,
+	// since we pull it if it isn't there, and have a shell with 'stat' and
+	// 'readlink', which we use to list the volume's files. Defaults to
+	// "busybox:latest"
+	Image string `yaml:"image,omitempty"`
+}
+
 type LogsConfig struct {
 	Timestamps bool   `yaml:"timestamps,omitempty"`
 	Since      string `yaml:"since,omitempty"`
@@ -398,6 +451,7 @@ func GetDefaultConfig() UserConfig {
 			CheckDockerComposeConfig: "{{ .DockerCompose }} config --quiet",
 			ServiceTop:               "{{ .DockerCompose }} --project-name {{ .Service.ProjectName }} top {{ .Service.Name }}",
 			PreferredExecShells:      []string{},
+			ScanImage:                "trivy image --quiet --format json {{ .Image.ID }}",
 		},
 		CustomCommands: CustomCommands{
 			Containers: []CustomCommand{},
@@ -470,6 +524,17 @@ func GetDefaultConfig() UserConfig {
 		Replacements: Replacements{
 			ImageNamePrefixes: map[string]string{},
 		},
+		Attach: AttachConfig{
+			DetachKeys: "ctrl-p,ctrl-q",
+		},
+		UpdateCheck: UpdateCheckConfig{
+			Enabled:      false,
+			Interval:     6 * time.Hour,
+			RequestDelay: 2 * time.Second,
+		},
+		VolumeHelper: VolumeHelperConfig{
+			Image: "busybox:latest",
+		},
 	}
 }
 
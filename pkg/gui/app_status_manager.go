@@ -5,19 +5,25 @@ import (
 
 	"github.com/jesseduffield/gocui"
 	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/sasha-s/go-deadlock"
 )
 
 type appStatus struct {
 	name       string
 	statusType string
 	duration   int
+	progress   string
 }
 
 type statusManager struct {
 	statuses []appStatus
+	mutex    deadlock.Mutex
 }
 
 func (m *statusManager) removeStatus(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	newStatuses := []appStatus{}
 	for _, status := range m.statuses {
 		if status.name != name {
@@ -29,6 +35,10 @@ func (m *statusManager) removeStatus(name string) {
 
 func (m *statusManager) addWaitingStatus(name string) {
 	m.removeStatus(name)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	newStatus := appStatus{
 		name:       name,
 		statusType: "waiting",
@@ -37,19 +47,45 @@ func (m *statusManager) addWaitingStatus(name string) {
 	m.statuses = append([]appStatus{newStatus}, m.statuses...)
 }
 
+func (m *statusManager) setProgress(name string, progress string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i := range m.statuses {
+		if m.statuses[i].name == name {
+			m.statuses[i].progress = progress
+		}
+	}
+}
+
 func (m *statusManager) getStatusString() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	if len(m.statuses) == 0 {
 		return ""
 	}
 	topStatus := m.statuses[0]
+	name := topStatus.name
+	if topStatus.progress != "" {
+		name += " " + topStatus.progress
+	}
 	if topStatus.statusType == "waiting" {
-		return topStatus.name + " " + utils.Loader()
+		return name + " " + utils.Loader()
 	}
-	return topStatus.name
+	return name
 }
 
 // WithWaitingStatus wraps a function and shows a waiting status while the function is still executing
 func (gui *Gui) WithWaitingStatus(name string, f func() error) error {
+	return gui.WithProgressStatus(name, func(func(string)) error {
+		return f()
+	})
+}
+
+// WithProgressStatus is like WithWaitingStatus except that the function is
+// given a callback for reporting its progress, which is shown next to the status
+func (gui *Gui) WithProgressStatus(name string, f func(onProgress func(string)) error) error {
 	go func() {
 		gui.statusManager.addWaitingStatus(name)
 
@@ -71,7 +107,11 @@ func (gui *Gui) WithWaitingStatus(name string, f func() error) error {
 			}
 		}()
 
-		if err := f(); err != nil {
+		onProgress := func(progress string) {
+			gui.statusManager.setProgress(name, progress)
+		}
+
+		if err := f(onProgress); err != nil {
 			gui.g.Update(func(g *gocui.Gui) error {
 				return gui.createErrorPanel(err.Error())
 			})
@@ -0,0 +1,454 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/config"
+	"github.com/peauc/lazydocker-ng/pkg/gui/panels"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// the most marked items we act on at the same time, so that we don't flood
+// the docker daemon (or the machine, for custom commands)
+const batchConcurrency = 8
+
+// batchItem is one of the marked items a batch action runs on
+type batchItem struct {
+	name string
+	run  func() error
+}
+
+// runBatch runs an action on each of the marked items in a panel at the same
+// time, then unmarks them and shows how the action went for each of them
+func (gui *Gui) runBatch(panel panels.ISideListPanel, title string, status string, items []batchItem, refresh func() error) error {
+	return gui.WithWaitingStatus(status, func() error {
+		errs := make([]error, len(items))
+		semaphore := make(chan struct{}, batchConcurrency)
+		var wg sync.WaitGroup
+		for i, item := range items {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				errs[i] = item.run()
+				if errs[i] != nil {
+					gui.Log.Error(errs[i])
+				}
+			}()
+		}
+		wg.Wait()
+
+		gui.Update(func() error {
+			panel.ClearMarks()
+			return panel.RerenderList()
+		})
+		if err := refresh(); err != nil {
+			gui.Log.Error(err)
+		}
+
+		return gui.createConfirmationPanel(title, gui.batchReportStr(items, errs), nil, nil)
+	})
+}
+
+func (gui *Gui) batchReportStr(items []batchItem, errs []error) string {
+	rows := lo.Map(items, func(item batchItem, i int) []string {
+		if errs[i] != nil {
+			// docker's errors can run over several lines
+			message, _, _ := strings.Cut(errs[i].Error(), "\n")
+			return []string{item.name, utils.ColoredString(utils.SafeTruncate(message, 80), color.FgRed)}
+		}
+		return []string{item.name, utils.ColoredString(gui.Tr.BatchItemDone, color.FgGreen)}
+	})
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.BatchReport, map[string]string{
+		"succeeded": fmt.Sprint(lo.CountBy(errs, func(err error) bool { return err == nil })),
+		"count":     fmt.Sprint(len(items)),
+	})
+
+	return summary + "\n\n" + table
+}
+
+// confirmStopMarked asks before stopping the marked items with the given names
+func (gui *Gui) confirmStopMarked(names []string, stop func() error) error {
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmStopMarked, map[string]string{
+		"count": fmt.Sprint(len(names)),
+		"items": strings.Join(names, "\n"),
+	})
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		return stop()
+	}, nil)
+}
+
+// batchRemoveMenu offers a few ways of removing the marked items, like the
+// remove menu does for the selected item
+func (gui *Gui) batchRemoveMenu(count int, options []*types.MenuItem) error {
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.BatchRemoveTitle, map[string]string{"count": fmt.Sprint(count)}),
+		Items: options,
+	})
+}
+
+// batchCommandTarget is a marked item a custom command runs against
+type batchCommandTarget struct {
+	name   string
+	object commands.CommandObject
+	// why the command can't run against the item, if it can't
+	err error
+}
+
+// batchCommand is a custom command along with the marked items it runs against
+type batchCommand struct {
+	command config.CustomCommand
+	targets []batchCommandTarget
+}
+
+// batchCustomCommandMenu lists the custom commands that can run against the
+// marked items, running the chosen one against each of them at once. Commands
+// which attach to a terminal can only run against one item at a time.
+func (gui *Gui) batchCustomCommandMenu(panel panels.ISideListPanel, count int, batchCommands []batchCommand, refresh func() error) error {
+	batchCommands = lo.Filter(batchCommands, func(batchCommand batchCommand, _ int) bool {
+		return !batchCommand.command.Attach && batchCommand.command.InternalFunction == nil
+	})
+	if len(batchCommands) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoBatchCustomCommands)
+	}
+
+	menuItems := lo.Map(batchCommands, func(batchCommand batchCommand, _ int) *types.MenuItem {
+		command := batchCommand.command
+
+		return &types.MenuItem{
+			LabelColumns: []string{command.Name, utils.ColoredString(command.Command, color.FgCyan)},
+			OnPress: func() error {
+				items := lo.Map(batchCommand.targets, func(target batchCommandTarget, _ int) batchItem {
+					return batchItem{
+						name: target.name,
+						run: func() error {
+							if target.err != nil {
+								return target.err
+							}
+							resolvedCommand := utils.ApplyTemplate(command.Command, target.object)
+							if command.Shell {
+								resolvedCommand = gui.OSCommand.NewCommandStringWithShell(resolvedCommand)
+							}
+							return gui.OSCommand.RunCommand(resolvedCommand)
+						},
+					}
+				})
+
+				return gui.runBatch(panel, command.Name, gui.Tr.RunningCustomCommandStatus, items, refresh)
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.BatchCustomCommandTitle, map[string]string{"count": fmt.Sprint(count)}),
+		Items: menuItems,
+	})
+}
+
+func containerBatchItems(containers []*commands.Container, run func(*commands.Container) error) []batchItem {
+	return lo.Map(containers, func(ctr *commands.Container, _ int) batchItem {
+		return batchItem{name: ctr.Name, run: func() error { return run(ctr) }}
+	})
+}
+
+// batchContainerStartStop starts the marked containers which have exited and
+// stops the running ones
+func (gui *Gui) batchContainerStartStop(containers []*commands.Container) error {
+	status := gui.Tr.StoppingStatus
+	startStop := func() error {
+		items := containerBatchItems(containers, func(ctr *commands.Container) error {
+			switch ctr.Container.State {
+			case "exited":
+				return ctr.Start()
+			case "running":
+				return ctr.Stop()
+			default:
+				return errors.New(gui.Tr.CannotStartStop)
+			}
+		})
+		return gui.runBatch(gui.Panels.Containers, gui.Tr.StartStop, status, items, gui.refreshContainersAndServices)
+	}
+
+	running := lo.FilterMap(containers, func(ctr *commands.Container, _ int) (string, bool) {
+		return ctr.Name, ctr.Container.State == "running"
+	})
+	if len(running) == 0 {
+		status = gui.Tr.StartingStatus
+		return startStop()
+	}
+	if lo.SomeBy(containers, func(ctr *commands.Container) bool { return ctr.Container.State == "exited" }) {
+		status = gui.Tr.StartingStoppingStatus
+	}
+
+	return gui.confirmStopMarked(running, startStop)
+}
+
+func (gui *Gui) batchContainerRestart(containers []*commands.Container) error {
+	items := containerBatchItems(containers, func(ctr *commands.Container) error {
+		return ctr.Restart()
+	})
+
+	return gui.runBatch(gui.Panels.Containers, gui.Tr.Restart, gui.Tr.RestartingStatus, items, gui.refreshContainersAndServices)
+}
+
+// batchContainerPause pauses the marked containers, or unpauses them if
+// they're paused
+func (gui *Gui) batchContainerPause(containers []*commands.Container) error {
+	items := containerBatchItems(containers, func(ctr *commands.Container) error {
+		if ctr.Container.State == "paused" {
+			return ctr.Unpause()
+		}
+		return ctr.Pause()
+	})
+
+	return gui.runBatch(gui.Panels.Containers, gui.Tr.Pause, gui.Tr.PausingStatus, items, gui.refreshContainersAndServices)
+}
+
+func (gui *Gui) batchContainerRemoveMenu(containers []*commands.Container) error {
+	remove := func(options container.RemoveOptions) func() error {
+		return func() error {
+			items := containerBatchItems(containers, func(ctr *commands.Container) error {
+				return ctr.Remove(options)
+			})
+			return gui.runBatch(gui.Panels.Containers, gui.Tr.Remove, gui.Tr.RemovingStatus, items, gui.refreshContainersAndServices)
+		}
+	}
+
+	return gui.batchRemoveMenu(len(containers), []*types.MenuItem{
+		{LabelColumns: []string{gui.Tr.Remove}, OnPress: remove(container.RemoveOptions{})},
+		{LabelColumns: []string{gui.Tr.RemoveWithVolumes}, OnPress: remove(container.RemoveOptions{RemoveVolumes: true})},
+		{LabelColumns: []string{gui.Tr.RemoveWithForce}, OnPress: remove(container.RemoveOptions{Force: true})},
+	})
+}
+
+func (gui *Gui) batchContainersCustomCommand(containers []*commands.Container) error {
+	targets := lo.Map(containers, func(ctr *commands.Container, _ int) batchCommandTarget {
+		return batchCommandTarget{
+			name:   ctr.Name,
+			object: gui.DockerCommand.NewCommandObject(commands.CommandObject{Container: ctr}),
+		}
+	})
+
+	batchCommands := lo.Map(gui.Config.UserConfig.CustomCommands.Containers, func(command config.CustomCommand, _ int) batchCommand {
+		return batchCommand{command: command, targets: targets}
+	})
+
+	return gui.batchCustomCommandMenu(gui.Panels.Containers, len(containers), batchCommands, gui.refreshContainersAndServices)
+}
+
+func serviceBatchItems(services []*commands.Service, run func(*commands.Service) error) []batchItem {
+	return lo.Map(services, func(service *commands.Service, _ int) batchItem {
+		return batchItem{name: service.Name, run: func() error { return run(service) }}
+	})
+}
+
+func (gui *Gui) batchServiceStop(services []*commands.Service) error {
+	names := lo.Map(services, func(service *commands.Service, _ int) string { return service.Name })
+
+	return gui.confirmStopMarked(names, func() error {
+		items := serviceBatchItems(services, func(service *commands.Service) error {
+			return service.Stop()
+		})
+		return gui.runBatch(gui.Panels.Services, gui.Tr.Stop, gui.Tr.StoppingStatus, items, gui.refreshContainersAndServices)
+	})
+}
+
+func (gui *Gui) batchServiceStart(services []*commands.Service) error {
+	items := serviceBatchItems(services, func(service *commands.Service) error {
+		return service.Start()
+	})
+
+	return gui.runBatch(gui.Panels.Services, gui.Tr.Start, gui.Tr.StartingStatus, items, gui.refreshContainersAndServices)
+}
+
+func (gui *Gui) batchServiceRestart(services []*commands.Service) error {
+	items := serviceBatchItems(services, func(service *commands.Service) error {
+		return service.Restart()
+	})
+
+	return gui.runBatch(gui.Panels.Services, gui.Tr.Restart, gui.Tr.RestartingStatus, items, gui.refreshContainersAndServices)
+}
+
+func (gui *Gui) batchServicePause(services []*commands.Service) error {
+	items := serviceBatchItems(services, func(service *commands.Service) error {
+		if service.Container == nil {
+			return errors.New(gui.Tr.NoContainer)
+		}
+		if service.Container.Container.State == "paused" {
+			return service.Container.Unpause()
+		}
+		return service.Container.Pause()
+	})
+
+	return gui.runBatch(gui.Panels.Services, gui.Tr.Pause, gui.Tr.PausingStatus, items, gui.refreshContainersAndServices)
+}
+
+func (gui *Gui) batchServiceRemoveMenu(services []*commands.Service) error {
+	remove := func(withVolumes bool) func() error {
+		return func() error {
+			items := serviceBatchItems(services, func(service *commands.Service) error {
+				return gui.OSCommand.RunCommand(gui.serviceRemoveCommand(service, withVolumes))
+			})
+			return gui.runBatch(gui.Panels.Services, gui.Tr.Remove, gui.Tr.RemovingStatus, items, gui.refreshContainersAndServices)
+		}
+	}
+
+	return gui.batchRemoveMenu(len(services), []*types.MenuItem{
+		{LabelColumns: []string{gui.Tr.Remove}, OnPress: remove(false)},
+		{LabelColumns: []string{gui.Tr.RemoveWithVolumes}, OnPress: remove(true)},
+	})
+}
+
+// batchServicesCustomCommand offers the service commands that apply to all of
+// the marked services, and the container commands, which fail for services
+// without a container
+func (gui *Gui) batchServicesCustomCommand(services []*commands.Service) error {
+	serviceTargets := lo.Map(services, func(service *commands.Service, _ int) batchCommandTarget {
+		return batchCommandTarget{
+			name: service.Name,
+			object: gui.DockerCommand.NewCommandObject(commands.CommandObject{
+				Service:   service,
+				Container: service.Container,
+			}),
+		}
+	})
+	containerTargets := lo.Map(services, func(service *commands.Service, i int) batchCommandTarget {
+		target := serviceTargets[i]
+		if service.Container == nil {
+			target.err = errors.New(gui.Tr.NoContainer)
+		}
+		return target
+	})
+
+	batchCommands := lo.FilterMap(gui.Config.UserConfig.CustomCommands.Services, func(command config.CustomCommand, _ int) (batchCommand, bool) {
+		appliesToAll := len(command.ServiceNames) == 0 || lo.EveryBy(services, func(service *commands.Service) bool {
+			return lo.Contains(command.ServiceNames, service.Name)
+		})
+		return batchCommand{command: command, targets: serviceTargets}, appliesToAll
+	})
+	for _, command := range gui.Config.UserConfig.CustomCommands.Containers {
+		batchCommands = append(batchCommands, batchCommand{command: command, targets: containerTargets})
+	}
+
+	return gui.batchCustomCommandMenu(gui.Panels.Services, len(services), batchCommands, gui.refreshContainersAndServices)
+}
+
+// the name we list an image under when reporting on a batch action: its first
+// tag, or its short ID if it has none
+func imageBatchName(img *commands.Image) string {
+	if tags := img.RepoTags(); len(tags) > 0 {
+		return tags[0]
+	}
+	return utils.SafeTruncate(strings.TrimPrefix(img.ID, "sha256:"), 10)
+}
+
+func (gui *Gui) batchImageRemoveMenu(images []*commands.Image) error {
+	remove := func(options image.RemoveOptions) func() error {
+		return func() error {
+			items := lo.Map(images, func(img *commands.Image, _ int) batchItem {
+				return batchItem{name: imageBatchName(img), run: func() error { return img.Remove(options) }}
+			})
+			return gui.runBatch(gui.Panels.Images, gui.Tr.Remove, gui.Tr.RemovingStatus, items, gui.reloadImages)
+		}
+	}
+
+	return gui.batchRemoveMenu(len(images), []*types.MenuItem{
+		{LabelColumns: []string{gui.Tr.Remove}, OnPress: remove(image.RemoveOptions{PruneChildren: true})},
+		{LabelColumns: []string{gui.Tr.RemoveWithoutPrune}, OnPress: remove(image.RemoveOptions{})},
+		{LabelColumns: []string{gui.Tr.RemoveWithForce}, OnPress: remove(image.RemoveOptions{PruneChildren: true, Force: true})},
+		{LabelColumns: []string{gui.Tr.RemoveWithoutPruneWithForce}, OnPress: remove(image.RemoveOptions{Force: true})},
+	})
+}
+
+func (gui *Gui) batchImagesCustomCommand(images []*commands.Image) error {
+	targets := lo.Map(images, func(img *commands.Image, _ int) batchCommandTarget {
+		return batchCommandTarget{
+			name:   imageBatchName(img),
+			object: gui.DockerCommand.NewCommandObject(commands.CommandObject{Image: img}),
+		}
+	})
+
+	batchCommands := lo.Map(gui.Config.UserConfig.CustomCommands.Images, func(command config.CustomCommand, _ int) batchCommand {
+		return batchCommand{command: command, targets: targets}
+	})
+
+	return gui.batchCustomCommandMenu(gui.Panels.Images, len(images), batchCommands, gui.reloadImages)
+}
+
+func (gui *Gui) batchVolumeRemoveMenu(volumes []*commands.Volume) error {
+	remove := func(force bool) func() error {
+		return func() error {
+			items := lo.Map(volumes, func(volume *commands.Volume, _ int) batchItem {
+				return batchItem{name: volume.Name, run: func() error { return volume.Remove(force) }}
+			})
+			return gui.runBatch(gui.Panels.Volumes, gui.Tr.Remove, gui.Tr.RemovingStatus, items, gui.reloadVolumes)
+		}
+	}
+
+	return gui.batchRemoveMenu(len(volumes), []*types.MenuItem{
+		{LabelColumns: []string{gui.Tr.Remove}, OnPress: remove(false)},
+		{LabelColumns: []string{gui.Tr.ForceRemove}, OnPress: remove(true)},
+	})
+}
+
+func (gui *Gui) batchVolumesCustomCommand(volumes []*commands.Volume) error {
+	targets := lo.Map(volumes, func(volume *commands.Volume, _ int) batchCommandTarget {
+		return batchCommandTarget{
+			name:   volume.Name,
+			object: gui.DockerCommand.NewCommandObject(commands.CommandObject{Volume: volume}),
+		}
+	})
+
+	batchCommands := lo.Map(gui.Config.UserConfig.CustomCommands.Volumes, func(command config.CustomCommand, _ int) batchCommand {
+		return batchCommand{command: command, targets: targets}
+	})
+
+	return gui.batchCustomCommandMenu(gui.Panels.Volumes, len(volumes), batchCommands, gui.reloadVolumes)
+}
+
+func (gui *Gui) batchNetworkRemoveMenu(networks []*commands.Network) error {
+	return gui.batchRemoveMenu(len(networks), []*types.MenuItem{
+		{
+			LabelColumns: []string{gui.Tr.Remove},
+			OnPress: func() error {
+				items := lo.Map(networks, func(network *commands.Network, _ int) batchItem {
+					return batchItem{name: network.Name, run: network.Remove}
+				})
+				return gui.runBatch(gui.Panels.Networks, gui.Tr.Remove, gui.Tr.RemovingStatus, items, gui.reloadNetworks)
+			},
+		},
+	})
+}
+
+func (gui *Gui) batchNetworksCustomCommand(networks []*commands.Network) error {
+	targets := lo.Map(networks, func(network *commands.Network, _ int) batchCommandTarget {
+		return batchCommandTarget{
+			name:   network.Name,
+			object: gui.DockerCommand.NewCommandObject(commands.CommandObject{Network: network}),
+		}
+	})
+
+	batchCommands := lo.Map(gui.Config.UserConfig.CustomCommands.Networks, func(command config.CustomCommand, _ int) batchCommand {
+		return batchCommand{command: command, targets: targets}
+	})
+
+	return gui.batchCustomCommandMenu(gui.Panels.Networks, len(networks), batchCommands, gui.reloadNetworks)
+}
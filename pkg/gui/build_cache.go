@@ -0,0 +1,218 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/go-units"
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+const buildCacheKey = "build-cache"
+
+// the most build cache records we list when previewing a prune
+const buildCachePrunePreviewLimit = 20
+
+func (gui *Gui) handleBuildCache(g *gocui.Gui, v *gocui.View) error {
+	if err := gui.loadBuildCache(); err != nil {
+		return err
+	}
+
+	return gui.focusMain()
+}
+
+// handleMainBuildCachePrune opens the prune menu when the main view is listing
+// the build cache. The key means nothing for the main view's other lists.
+func (gui *Gui) handleMainBuildCachePrune(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Panels.Main.ObjectKey != buildCacheKey {
+		return nil
+	}
+
+	return gui.handleMainListKey('p')(g, v)
+}
+
+// loadBuildCache lists the build cache records in the main view, where they
+// can be pruned
+func (gui *Gui) loadBuildCache() error {
+	return gui.loadMainList(buildCacheKey, gui.Tr.LoadingBuildCache, func(ctx context.Context) (*mainList, error) {
+		records, err := gui.DockerCommand.BuildCache(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return gui.buildCacheList(records), nil
+	})
+}
+
+func (gui *Gui) buildCacheList(records []*build.CacheRecord) *mainList {
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		preview, _ := commands.PreviewBuildCachePrune(records, commands.BuildCachePruneOptions{}, time.Now())
+		summary := utils.ResolvePlaceholderString(gui.Tr.BuildCacheSummary, map[string]string{
+			"count":       fmt.Sprint(len(records)),
+			"reclaimable": utils.FormatDecimalBytes(int(preview.Size)),
+		})
+		if len(records) == 0 {
+			return summary, nil
+		}
+
+		rows := [][]string{{
+			gui.Tr.IDColumn,
+			gui.Tr.TypeColumn,
+			gui.Tr.SizeColumn,
+			gui.Tr.LastUsedColumn,
+			gui.Tr.SharedColumn,
+			gui.Tr.InUseColumn,
+			gui.Tr.DescriptionColumn,
+		}}
+		for _, record := range records {
+			rows = append(rows, gui.renderBuildCacheRecord(record))
+		}
+
+		table, err := utils.RenderTable(rows)
+		if err != nil {
+			gui.Log.Error(err)
+			return summary, nil
+		}
+		lines := strings.Split(table, "\n")
+
+		return summary + "\n\n" + lines[0], lines[1:]
+	}
+
+	list.keyHandlers = map[rune]func(int) error{
+		'p': func(int) error { return gui.handleBuildCachePruneMenu(records) },
+	}
+
+	return list
+}
+
+func (gui *Gui) renderBuildCacheRecord(record *build.CacheRecord) []string {
+	lastUsed := record.CreatedAt
+	if record.LastUsedAt != nil {
+		lastUsed = *record.LastUsedAt
+	}
+
+	yesNo := func(value bool) string {
+		if value {
+			return utils.ColoredString(gui.Tr.Yes, color.FgGreen)
+		}
+		return utils.ColoredString(gui.Tr.No, color.FgBlue)
+	}
+
+	return []string{
+		utils.SafeTruncate(strings.TrimPrefix(record.ID, "sha256:"), 12),
+		utils.ColoredString(record.Type, color.FgMagenta),
+		utils.ColoredString(utils.FormatDecimalBytes(int(record.Size)), color.FgYellow),
+		utils.ResolvePlaceholderString(gui.Tr.TimeAgo, map[string]string{"duration": units.HumanDuration(time.Since(lastUsed))}),
+		yesNo(record.Shared),
+		yesNo(record.InUse),
+		record.Description,
+	}
+}
+
+func (gui *Gui) handleBuildCachePruneMenu(records []*build.CacheRecord) error {
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.PruneBuildCache,
+		Items: []*types.MenuItem{
+			{
+				Label: gui.Tr.PruneAllBuildCache,
+				OnPress: func() error {
+					return gui.previewBuildCachePrune(records, commands.BuildCachePruneOptions{})
+				},
+			},
+			{
+				Label: gui.Tr.PruneBuildCacheOlderThan,
+				OnPress: func() error {
+					return gui.createPromptPanelWithInitialContent(gui.Tr.BuildCacheUntilTitle, "24h", func(g *gocui.Gui, v *gocui.View) error {
+						until := gui.trimmedContent(v)
+						if _, err := commands.ParseUntil(until, time.Now()); err != nil {
+							return gui.createErrorPanel(err.Error())
+						}
+						return gui.previewBuildCachePrune(records, commands.BuildCachePruneOptions{Until: until})
+					})
+				},
+			},
+			{
+				Label: gui.Tr.PruneBuildCacheKeepStorage,
+				OnPress: func() error {
+					return gui.createPromptPanelWithInitialContent(gui.Tr.BuildCacheKeepStorageTitle, "10GB", func(g *gocui.Gui, v *gocui.View) error {
+						keepStorage, err := commands.ParseStorageSize(gui.trimmedContent(v))
+						if err != nil {
+							return gui.createErrorPanel(err.Error())
+						}
+						return gui.previewBuildCachePrune(records, commands.BuildCachePruneOptions{KeepStorage: keepStorage})
+					})
+				},
+			},
+		},
+	})
+}
+
+// previewBuildCachePrune lists the records a prune would remove, and how much
+// space it would free up, asking for confirmation before pruning
+func (gui *Gui) previewBuildCachePrune(records []*build.CacheRecord, options commands.BuildCachePruneOptions) error {
+	preview, err := commands.PreviewBuildCachePrune(records, options, time.Now())
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	if len(preview.Records) == 0 {
+		return gui.createConfirmationPanel(gui.Tr.PruneBuildCache, gui.Tr.NothingToPrune, nil, nil)
+	}
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, gui.buildCachePrunePreviewStr(preview), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.pruneBuildCache(options)
+	}, nil)
+}
+
+func (gui *Gui) buildCachePrunePreviewStr(preview *commands.BuildCachePrunePreview) string {
+	lines := []string{}
+	for i, record := range preview.Records {
+		if i == buildCachePrunePreviewLimit {
+			lines = append(lines, utils.ResolvePlaceholderString(gui.Tr.AndMore, map[string]string{
+				"count": fmt.Sprint(len(preview.Records) - buildCachePrunePreviewLimit),
+			}))
+			break
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s",
+			utils.ColoredString(fmt.Sprintf("%10s", utils.FormatDecimalBytes(int(record.Size))), color.FgYellow),
+			utils.SafeTruncate(record.Description, 60),
+		))
+	}
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.BuildCachePrunePreview, map[string]string{
+		"count": fmt.Sprint(len(preview.Records)),
+		"size":  utils.FormatDecimalBytes(int(preview.Size)),
+	})
+
+	return summary + "\n\n" + strings.Join(lines, "\n")
+}
+
+func (gui *Gui) pruneBuildCache(options commands.BuildCachePruneOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		report, err := gui.DockerCommand.PruneBuildCache(options)
+		if err != nil {
+			return err
+		}
+
+		gui.Update(func() error {
+			if gui.State.Panels.Main.ObjectKey != buildCacheKey {
+				return nil
+			}
+			return gui.loadBuildCache()
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.PruneBuildCache, utils.ResolvePlaceholderString(gui.Tr.BuildCachePruneReport, map[string]string{
+			"count": fmt.Sprint(len(report.CachesDeleted)),
+			"size":  utils.FormatDecimalBytes(int(report.SpaceReclaimed)),
+		}), nil, nil)
+	})
+}
@@ -0,0 +1,219 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// buildProgress tracks the output of an image build. With BuildKit we render
+// each step of the build with its status and output, the way `docker build
+// --progress=plain` does. With the legacy builder we just show its output.
+type buildProgress struct {
+	mutex       deadlock.Mutex
+	title       string
+	lines       []string
+	vertexIDs   []string
+	vertexes    map[string]*buildVertexProgress
+	result      string
+	failed      bool
+	finished    bool
+	failureLine int
+}
+
+type buildVertexProgress struct {
+	vertex   commands.BuildVertex
+	statuses []commands.BuildVertexStatus
+	output   string
+}
+
+func newBuildProgress(title string) *buildProgress {
+	return &buildProgress{
+		title:    title,
+		vertexes: map[string]*buildVertexProgress{},
+	}
+}
+
+func (p *buildProgress) update(event commands.BuildEvent) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if event.Status == nil {
+		p.lines = append(p.lines, strings.Split(strings.TrimSuffix(event.Stream, "\n"), "\n")...)
+		return
+	}
+
+	for _, vertex := range event.Status.Vertexes {
+		p.getVertex(vertex.Digest).vertex = vertex
+	}
+
+	for _, status := range event.Status.Statuses {
+		vertex := p.getVertex(status.Vertex)
+		replaced := false
+		for i, existing := range vertex.statuses {
+			if existing.ID == status.ID {
+				vertex.statuses[i] = status
+				replaced = true
+			}
+		}
+		if !replaced {
+			vertex.statuses = append(vertex.statuses, status)
+		}
+	}
+
+	for _, log := range event.Status.Logs {
+		vertex := p.getVertex(log.Vertex)
+		vertex.output += string(log.Msg)
+	}
+}
+
+func (p *buildProgress) getVertex(digest string) *buildVertexProgress {
+	vertex, ok := p.vertexes[digest]
+	if !ok {
+		vertex = &buildVertexProgress{vertex: commands.BuildVertex{Digest: digest}}
+		p.vertexes[digest] = vertex
+		p.vertexIDs = append(p.vertexIDs, digest)
+	}
+	return vertex
+}
+
+// finish marks the build as finished, with result being the line we show at
+// the end of the output, e.g. the error that failed the build
+func (p *buildProgress) finish(result string, failed bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.finished = true
+	p.result = result
+	p.failed = failed
+}
+
+func (p *buildProgress) isFinished() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.finished
+}
+
+// summary returns e.g. '3/7 steps' for the status bar
+func (p *buildProgress) summary() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.vertexIDs) == 0 {
+		return fmt.Sprintf("%d lines", len(p.lines))
+	}
+
+	done := 0
+	for _, id := range p.vertexIDs {
+		if p.vertexes[id].vertex.Completed != nil {
+			done++
+		}
+	}
+
+	return fmt.Sprintf("%d/%d steps", done, len(p.vertexIDs))
+}
+
+// getFailureLine returns the line of the rendered output at which the failing
+// step of a failed build starts
+func (p *buildProgress) getFailureLine() (int, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.failureLine, p.failed
+}
+
+func (p *buildProgress) render() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	lines := []string{utils.ColoredString(p.title, color.FgCyan), ""}
+	failedStepLine := -1
+
+	for _, line := range p.lines {
+		// the legacy builder's output has 'Step 2/5 : RUN make' before each step
+		if strings.HasPrefix(line, "Step ") {
+			failedStepLine = len(lines)
+			line = utils.ColoredString(line, color.FgCyan)
+		}
+		lines = append(lines, line)
+	}
+
+	failedVertex := false
+	for i, id := range p.vertexIDs {
+		vertex := p.vertexes[id]
+		// when a step fails, BuildKit cancels the steps running alongside it,
+		// which then fail too, so we want the first step with an error
+		if vertex.vertex.Error != "" && !failedVertex {
+			failedStepLine = len(lines)
+			failedVertex = true
+		}
+		lines = append(lines, vertex.render(i+1)...)
+	}
+
+	p.failureLine = failedStepLine
+	if p.failureLine == -1 {
+		p.failureLine = len(lines) + 1
+	}
+
+	if p.result != "" {
+		colour := color.FgGreen
+		if p.failed {
+			colour = color.FgRed
+		}
+		lines = append(lines, "", utils.ColoredString(p.result, colour))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (v *buildVertexProgress) render(number int) []string {
+	header := utils.ColoredString(fmt.Sprintf("#%d", number), color.FgBlue) + " " + v.vertex.Name
+
+	switch {
+	case v.vertex.Error != "":
+		header += " " + utils.ColoredString("ERROR", color.FgRed)
+	case v.vertex.Cached:
+		header += " " + utils.ColoredString("CACHED", color.FgBlue)
+	case v.vertex.Completed != nil && v.vertex.Started != nil:
+		header += " " + utils.ColoredString(fmt.Sprintf("DONE %.1fs", v.vertex.Completed.Sub(*v.vertex.Started).Seconds()), color.FgGreen)
+	case v.vertex.Started != nil:
+		header += " " + utils.ColoredString("...", color.FgYellow)
+	}
+
+	lines := []string{header}
+
+	for _, status := range v.statuses {
+		line := "  " + status.Name
+		if status.Name == "" {
+			line = "  " + status.ID
+		}
+		switch {
+		case status.Completed:
+			line += " " + utils.ColoredString("done", color.FgGreen)
+		case status.Total > 0:
+			line += fmt.Sprintf(" %s %s/%s", renderProgressBar(status.Current, status.Total), utils.FormatBinaryBytes(int(status.Current)), utils.FormatBinaryBytes(int(status.Total)))
+		}
+		lines = append(lines, line)
+	}
+
+	if output := strings.TrimRight(v.output, "\n"); output != "" {
+		for _, line := range strings.Split(output, "\n") {
+			// programs drawing their own progress bars overwrite the line with '\r'
+			if idx := strings.LastIndex(line, "\r"); idx != -1 {
+				line = line[idx+1:]
+			}
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	if v.vertex.Error != "" {
+		lines = append(lines, "  "+utils.ColoredString(v.vertex.Error, color.FgRed))
+	}
+
+	return lines
+}
@@ -0,0 +1,71 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildProgressBuildKit(t *testing.T) {
+	progress := newBuildProgress("Building .")
+
+	started := time.Unix(100, 0)
+	completed := time.Unix(101, 0)
+
+	progress.update(commands.BuildEvent{Status: &commands.BuildStatus{
+		Vertexes: []commands.BuildVertex{
+			{Digest: "a", Name: "[1/2] FROM alpine", Cached: true},
+			{Digest: "b", Name: "[2/2] RUN make", Started: &started},
+		},
+	}})
+	progress.update(commands.BuildEvent{Status: &commands.BuildStatus{
+		Logs: []commands.BuildVertexLog{{Vertex: "b", Msg: []byte("compiling 10%\rcompiling 100%\nlinking")}},
+	}})
+	progress.update(commands.BuildEvent{Status: &commands.BuildStatus{
+		Vertexes: []commands.BuildVertex{
+			{Digest: "b", Name: "[2/2] RUN make", Started: &started, Completed: &completed, Error: "exit code: 2"},
+		},
+	}})
+
+	assert.EqualValues(t, "1/2 steps", progress.summary())
+
+	progress.finish("failed to solve", true)
+	lines := strings.Split(utils.Decolorise(progress.render()), "\n")
+	assert.EqualValues(t, []string{
+		"Building .",
+		"",
+		"#1 [1/2] FROM alpine CACHED",
+		"#2 [2/2] RUN make ERROR",
+		"  compiling 100%",
+		"  linking",
+		"  exit code: 2",
+		"",
+		"failed to solve",
+	}, lines)
+
+	line, failed := progress.getFailureLine()
+	assert.True(t, failed)
+	assert.EqualValues(t, 3, line)
+}
+
+func TestBuildProgressLegacy(t *testing.T) {
+	progress := newBuildProgress("Building .")
+
+	progress.update(commands.BuildEvent{Stream: "Step 1/2 : FROM alpine\n"})
+	progress.update(commands.BuildEvent{Stream: " ---> 1234\n"})
+	progress.update(commands.BuildEvent{Stream: "Step 2/2 : RUN make\n"})
+	progress.update(commands.BuildEvent{Stream: "make: not found\n"})
+
+	assert.EqualValues(t, "4 lines", progress.summary())
+
+	progress.finish("exit code: 127", true)
+	progress.render()
+
+	line, failed := progress.getFailureLine()
+	assert.True(t, failed)
+	assert.EqualValues(t, 4, line)
+}
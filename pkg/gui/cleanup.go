@@ -0,0 +1,219 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// handleCleanup is an interactive `docker system prune`: it shows how many
+// things of each kind could be pruned, and how much space that would reclaim,
+// letting the user pick which kinds to prune
+func (gui *Gui) handleCleanup(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.PreparingPruneStatus, func() error {
+		du, err := gui.DockerCommand.RawDiskUsage(context.Background())
+		if err != nil {
+			return err
+		}
+
+		state := &commands.CleanupState{
+			Containers: gui.Panels.Containers.List.GetAllItems(),
+			Images:     gui.Panels.Images.List.GetAllItems(),
+			Networks:   gui.Panels.Networks.List.GetAllItems(),
+			Volumes:    gui.Panels.Volumes.List.GetAllItems(),
+			DiskUsage:  du,
+		}
+
+		// the same kinds `docker system prune` prunes by default
+		selected := map[commands.CleanupKind]bool{
+			commands.CleanupContainers:         true,
+			commands.CleanupNetworks:           true,
+			commands.CleanupDanglingImages:     true,
+			commands.CleanupDanglingBuildCache: true,
+		}
+
+		gui.Update(func() error {
+			return gui.cleanupMenu(state, selected, 0)
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) cleanupKindLabel(kind commands.CleanupKind) string {
+	switch kind {
+	case commands.CleanupContainers:
+		return gui.Tr.CleanupStoppedContainers
+	case commands.CleanupNetworks:
+		return gui.Tr.CleanupUnusedNetworks
+	case commands.CleanupDanglingImages:
+		return gui.Tr.PruneDanglingImages
+	case commands.CleanupUnusedImages:
+		return gui.Tr.PruneUnusedImages
+	case commands.CleanupVolumes:
+		return gui.Tr.PruneUnusedVolumes
+	case commands.CleanupDanglingBuildCache:
+		return gui.Tr.PruneDanglingBuildCache
+	default:
+		return gui.Tr.PruneAllBuildCache
+	}
+}
+
+// selectedCleanup returns what the cleanup would remove of each selected kind.
+// Pruning e.g. unused images prunes the dangling ones too, so we leave out the
+// kinds that a selected kind includes.
+func selectedCleanup(plan []*commands.CleanupCandidates, selected map[commands.CleanupKind]bool) []*commands.CleanupCandidates {
+	return lo.Filter(plan, func(candidates *commands.CleanupCandidates, _ int) bool {
+		for kind, included := range commands.CleanupIncludes {
+			if candidates.Kind == included && selected[kind] {
+				return false
+			}
+		}
+		return selected[candidates.Kind]
+	})
+}
+
+// cleanupMenu lists the kinds of things the cleanup can prune with a checkbox
+// each. Toggling a kind re-opens the menu with the counts and sizes worked out
+// again, because pruning stopped containers frees up what they were using.
+func (gui *Gui) cleanupMenu(state *commands.CleanupState, selected map[commands.CleanupKind]bool, selectedIdx int) error {
+	plan, err := commands.PlanCleanup(state, selected[commands.CleanupContainers], time.Now())
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	chosen := selectedCleanup(plan, selected)
+	count := lo.SumBy(chosen, func(candidates *commands.CleanupCandidates) int { return len(candidates.Items) })
+	size := lo.SumBy(chosen, func(candidates *commands.CleanupCandidates) int64 { return candidates.Size })
+
+	action := &types.MenuItem{
+		LabelColumns: []string{gui.Tr.RunCleanup},
+		OnPress: func() error {
+			if count == 0 {
+				return gui.createConfirmationPanel(gui.Tr.CleanUp, gui.Tr.NothingToPrune, nil, nil)
+			}
+			return gui.confirmCleanup(chosen)
+		},
+	}
+
+	options := lo.Map(plan, func(candidates *commands.CleanupCandidates, _ int) checkboxMenuItem {
+		sizeStr := ""
+		if candidates.Kind != commands.CleanupNetworks {
+			sizeStr = utils.ColoredString(utils.FormatDecimalBytes(int(candidates.Size)), color.FgYellow)
+		}
+
+		return checkboxMenuItem{
+			LabelColumns: []string{gui.cleanupKindLabel(candidates.Kind), fmt.Sprint(len(candidates.Items)), sizeStr},
+			Checked:      selected[candidates.Kind],
+			OnToggle: func() {
+				selected[candidates.Kind] = !selected[candidates.Kind]
+				if !selected[candidates.Kind] {
+					return
+				}
+				// one or the other, where one kind includes the other
+				for kind, included := range commands.CleanupIncludes {
+					switch candidates.Kind {
+					case kind:
+						selected[included] = false
+					case included:
+						selected[kind] = false
+					}
+				}
+			},
+		}
+	})
+
+	title := utils.ResolvePlaceholderString(gui.Tr.CleanupTitle, map[string]string{
+		"count": fmt.Sprint(count),
+		"size":  utils.FormatDecimalBytes(int(size)),
+	})
+	return gui.checkboxMenu(title, action, options, selectedIdx, func(selectedIdx int) error {
+		return gui.cleanupMenu(state, selected, selectedIdx)
+	})
+}
+
+func (gui *Gui) confirmCleanup(chosen []*commands.CleanupCandidates) error {
+	rows := lo.FilterMap(chosen, func(candidates *commands.CleanupCandidates, _ int) ([]string, bool) {
+		if len(candidates.Items) == 0 {
+			return nil, false
+		}
+		return []string{
+			gui.cleanupKindLabel(candidates.Kind),
+			fmt.Sprint(len(candidates.Items)),
+			utils.ColoredString(utils.FormatDecimalBytes(int(candidates.Size)), color.FgYellow),
+		}, true
+	})
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmCleanup, map[string]string{"summary": table})
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		kinds := lo.Map(chosen, func(candidates *commands.CleanupCandidates, _ int) commands.CleanupKind {
+			return candidates.Kind
+		})
+		return gui.runCleanup(kinds)
+	}, nil)
+}
+
+func (gui *Gui) runCleanup(kinds []commands.CleanupKind) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		results := gui.DockerCommand.Cleanup(kinds)
+
+		// the report is what matters here, so we show it even if we can't
+		// reload the panels
+		for _, reload := range []func() error{gui.refreshContainersAndServices, gui.reloadNetworks, gui.reloadImages, gui.reloadVolumes} {
+			if err := reload(); err != nil {
+				gui.Log.Error(err)
+			}
+		}
+		gui.Update(func() error {
+			if gui.State.Panels.Main.ObjectKey != buildCacheKey {
+				return nil
+			}
+			return gui.loadBuildCache()
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.CleanUp, gui.cleanupReportStr(results), nil, nil)
+	})
+}
+
+// cleanupReportStr tells us how many things of each kind the cleanup removed
+// and how much space it reclaimed, or why it couldn't prune them
+func (gui *Gui) cleanupReportStr(results []*commands.CleanupResult) string {
+	rows := lo.Map(results, func(result *commands.CleanupResult, _ int) []string {
+		if result.Err != nil {
+			return []string{gui.cleanupKindLabel(result.Kind), utils.ColoredString(result.Err.Error(), color.FgRed), ""}
+		}
+		return []string{
+			gui.cleanupKindLabel(result.Kind),
+			utils.ResolvePlaceholderString(gui.Tr.CleanupRemoved, map[string]string{"count": fmt.Sprint(len(result.Removed))}),
+			utils.ColoredString(utils.FormatDecimalBytes(int(result.SpaceReclaimed)), color.FgYellow),
+		}
+	})
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+
+	total := utils.ResolvePlaceholderString(gui.Tr.CleanupReport, map[string]string{
+		"size": utils.FormatDecimalBytes(int(lo.SumBy(results, func(result *commands.CleanupResult) uint64 {
+			return result.SpaceReclaimed
+		}))),
+	})
+
+	return strings.Join([]string{total, table}, "\n\n")
+}
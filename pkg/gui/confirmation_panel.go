@@ -63,15 +63,42 @@ func (gui *Gui) getConfirmationPanelDimensions(wrap bool, prompt string) (int, i
 }
 
 func (gui *Gui) createPromptPanel(title string, handleConfirm func(*gocui.Gui, *gocui.View) error) error {
+	return gui.createPromptPanelWithInitialContent(title, "", handleConfirm)
+}
+
+// createPromptPanelWithInitialContent is like createPromptPanel except the
+// prompt starts out containing the given text, e.g. a suggested value
+func (gui *Gui) createPromptPanelWithInitialContent(title string, initialContent string, handleConfirm func(*gocui.Gui, *gocui.View) error) error {
 	gui.onNewPopupPanel()
 	err := gui.prepareConfirmationPanel(title, "")
 	if err != nil {
 		return err
 	}
 	gui.Views.Confirmation.Editable = true
+	gui.Views.Confirmation.ClearTextArea()
+	gui.Views.Confirmation.TextArea.TypeString(initialContent)
+	gui.Views.Confirmation.RenderTextArea()
 	return gui.setKeyBindings(gui.g, handleConfirm, nil)
 }
 
+// createValidatedPromptPanel is like createPromptPanelWithInitialContent except
+// that what the user enters is first passed to validate. If that returns an
+// error we show it and then prompt again with what they entered, so that they
+// can fix it rather than starting over.
+func (gui *Gui) createValidatedPromptPanel(title string, initialContent string, validate func(string) error, handleConfirm func(string) error) error {
+	return gui.createPromptPanelWithInitialContent(title, initialContent, func(g *gocui.Gui, v *gocui.View) error {
+		content := gui.trimmedContent(v)
+		if err := validate(content); err != nil {
+			retry := func(*gocui.Gui, *gocui.View) error {
+				return gui.createValidatedPromptPanel(title, content, validate, handleConfirm)
+			}
+			return gui.createConfirmationPanel(gui.Tr.ErrorTitle, coloredError(err.Error()), retry, retry)
+		}
+
+		return handleConfirm(content)
+	})
+}
+
 func (gui *Gui) prepareConfirmationPanel(title, prompt string) error {
 	x0, y0, x1, y1 := gui.getConfirmationPanelDimensions(true, prompt)
 	confirmationView := gui.Views.Confirmation
@@ -140,9 +167,12 @@ func (gui *Gui) setKeyBindings(g *gocui.Gui, handleConfirm, handleClose func(*go
 }
 
 func (gui *Gui) createErrorPanel(message string) error {
+	return gui.createConfirmationPanel(gui.Tr.ErrorTitle, coloredError(message), nil, nil)
+}
+
+func coloredError(message string) string {
 	colorFunction := color.New(color.FgRed).SprintFunc()
-	coloredMessage := colorFunction(strings.TrimSpace(message))
-	return gui.createConfirmationPanel(gui.Tr.ErrorTitle, coloredMessage, nil, nil)
+	return colorFunction(strings.TrimSpace(message))
 }
 
 func (gui *Gui) renderConfirmationOptions() error {
@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+)
+
+func (gui *Gui) handleContainerCommit(g *gocui.Gui, v *gocui.View) error {
+	ctr, err := gui.Panels.Containers.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	return gui.createPromptPanelWithInitialContent(gui.Tr.CommitReferenceTitle, strings.ToLower(ctr.Name)+":snapshot", func(g *gocui.Gui, v *gocui.View) error {
+		reference := gui.trimmedContent(v)
+
+		return gui.createPromptPanel(gui.Tr.CommitAuthorTitle, func(g *gocui.Gui, v *gocui.View) error {
+			author := gui.trimmedContent(v)
+
+			return gui.createPromptPanel(gui.Tr.CommitMessageTitle, func(g *gocui.Gui, v *gocui.View) error {
+				message := gui.trimmedContent(v)
+
+				validate := func(content string) error {
+					_, err := commands.ParseCommitChanges(content)
+					return err
+				}
+				return gui.createValidatedPromptPanel(gui.Tr.CommitChangesTitle, "", validate, func(content string) error {
+					changes, err := commands.ParseCommitChanges(content)
+					if err != nil {
+						return err
+					}
+
+					return gui.commitContainer(ctr, reference, author, message, changes)
+				})
+			})
+		})
+	})
+}
+
+func (gui *Gui) commitContainer(ctr *commands.Container, reference, author, message string, changes []string) error {
+	return gui.WithWaitingStatus(gui.Tr.CommittingStatus, func() error {
+		imageID, err := ctr.Commit(reference, author, message, changes)
+		if err != nil {
+			return err
+		}
+
+		return gui.selectImage(imageID)
+	})
+}
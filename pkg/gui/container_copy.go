@@ -0,0 +1,219 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// the number of entries we list when previewing the copy of a directory
+const copyPreviewLimit = 20
+
+func (gui *Gui) handleContainerCopyMenu(g *gocui.Gui, v *gocui.View) error {
+	ctr, err := gui.Panels.Containers.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	return gui.createCopyMenu(ctr)
+}
+
+func (gui *Gui) handleServiceCopyMenu(g *gocui.Gui, v *gocui.View) error {
+	service, err := gui.Panels.Services.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	if service.Container == nil {
+		return gui.createErrorPanel(gui.Tr.NoContainers)
+	}
+
+	return gui.createCopyMenu(service.Container)
+}
+
+func (gui *Gui) createCopyMenu(ctr *commands.Container) error {
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.CopyFiles,
+		Items: []*types.MenuItem{
+			{
+				Label:   gui.Tr.CopyFromContainer,
+				OnPress: func() error { return gui.promptCopyFromContainer(ctr) },
+			},
+			{
+				Label:   gui.Tr.CopyToContainer,
+				OnPress: func() error { return gui.promptCopyToContainer(ctr) },
+			},
+		},
+	})
+}
+
+func (gui *Gui) promptCopyFromContainer(ctr *commands.Container) error {
+	return gui.createPromptPanel(gui.Tr.CopyFromContainerSourceTitle, func(g *gocui.Gui, v *gocui.View) error {
+		srcPath := gui.trimmedContent(v)
+		if srcPath == "" {
+			return nil
+		}
+
+		return gui.promptCopyFromContainerDestination(ctr, srcPath)
+	})
+}
+
+// promptCopyFromContainerDestination asks where on the host to copy the given
+// container path to, and then copies it
+func (gui *Gui) promptCopyFromContainerDestination(ctr *commands.Container, srcPath string) error {
+	return gui.createPromptPanelWithInitialContent(gui.Tr.CopyFromContainerDestinationTitle, path.Base(srcPath), func(g *gocui.Gui, v *gocui.View) error {
+		dstPath := gui.trimmedContent(v)
+		if dstPath == "" {
+			return nil
+		}
+
+		return gui.copyFromContainer(ctr, srcPath, dstPath)
+	})
+}
+
+func (gui *Gui) promptCopyToContainer(ctr *commands.Container) error {
+	return gui.createPromptPanel(gui.Tr.CopyToContainerSourceTitle, func(g *gocui.Gui, v *gocui.View) error {
+		srcPath := gui.trimmedContent(v)
+		if srcPath == "" {
+			return nil
+		}
+
+		initialDstPath := "/"
+		if ctr.DetailsLoaded() && ctr.Details.Config.WorkingDir != "" {
+			initialDstPath = ctr.Details.Config.WorkingDir
+		}
+
+		return gui.createPromptPanelWithInitialContent(gui.Tr.CopyToContainerDestinationTitle, initialDstPath, func(g *gocui.Gui, v *gocui.View) error {
+			dstPath := gui.trimmedContent(v)
+			if dstPath == "" {
+				return nil
+			}
+
+			return gui.copyToContainer(ctr, srcPath, dstPath)
+		})
+	})
+}
+
+func (gui *Gui) copyFromContainer(ctr *commands.Container, srcPath, dstPath string) error {
+	target := commands.HostCopyDestination(srcPath, dstPath)
+	_, statErr := os.Lstat(target)
+	exists := statErr == nil
+
+	run := func() error {
+		return gui.WithProgressStatus(gui.Tr.CopyingStatus, func(onProgress func(string)) error {
+			return ctr.CopyFromContainer(srcPath, dstPath, bytesProgress(onProgress))
+		})
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.PreparingCopyStatus, func() error {
+		stat, err := ctr.StatPath(srcPath)
+		if err != nil {
+			return err
+		}
+
+		if !stat.Mode.IsDir() {
+			return gui.confirmCopy(nil, srcPath, target, exists, run)
+		}
+
+		entries, err := ctr.ListPath(context.Background(), srcPath)
+		if err != nil {
+			return err
+		}
+
+		return gui.confirmCopy(entries, srcPath, target, exists, run)
+	})
+}
+
+func (gui *Gui) copyToContainer(ctr *commands.Container, srcPath, dstPath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.PreparingCopyStatus, func() error {
+		target := ctr.CopyDestination(srcPath, dstPath)
+		exists := ctr.PathExists(target)
+
+		var entries []commands.ArchiveEntry
+		if info.IsDir() {
+			if entries, err = commands.ListHostPath(srcPath); err != nil {
+				return err
+			}
+		}
+
+		return gui.confirmCopy(entries, srcPath, target, exists, func() error {
+			return gui.WithProgressStatus(gui.Tr.CopyingStatus, func(onProgress func(string)) error {
+				return ctr.CopyToContainer(srcPath, dstPath, bytesProgress(onProgress))
+			})
+		})
+	})
+}
+
+// confirmCopy asks the user to confirm a copy if the source is a directory (in
+// which case we show what's going to be copied) or if the copy would overwrite
+// something. Otherwise we just go ahead with it.
+func (gui *Gui) confirmCopy(entries []commands.ArchiveEntry, srcPath, target string, exists bool, run func() error) error {
+	if entries == nil && !exists {
+		return run()
+	}
+
+	sections := []string{}
+	if entries != nil {
+		sections = append(sections, gui.copyPreview(entries, srcPath))
+	}
+	if exists {
+		sections = append(sections, utils.ResolvePlaceholderString(gui.Tr.ConfirmCopyOverwrite, map[string]string{
+			"path": target,
+		}))
+	}
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, strings.Join(sections, "\n\n"), func(g *gocui.Gui, v *gocui.View) error {
+		return run()
+	}, nil)
+}
+
+func (gui *Gui) copyPreview(entries []commands.ArchiveEntry, srcPath string) string {
+	var size int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			size += entry.Size
+		}
+	}
+
+	lines := []string{utils.ResolvePlaceholderString(gui.Tr.CopyPreview, map[string]string{
+		"count": fmt.Sprint(len(entries)),
+		"size":  utils.FormatBinaryBytes(int(size)),
+		"path":  srcPath,
+	})}
+
+	for i, entry := range entries {
+		if i == copyPreviewLimit {
+			lines = append(lines, utils.ResolvePlaceholderString(gui.Tr.CopyPreviewMore, map[string]string{
+				"count": fmt.Sprint(len(entries) - copyPreviewLimit),
+			}))
+			break
+		}
+
+		if entry.IsDir() {
+			lines = append(lines, "  "+utils.ColoredString(entry.Path+"/", color.FgBlue))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s %s", entry.Path, utils.ColoredString(utils.FormatBinaryBytes(int(entry.Size)), color.FgYellow)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func bytesProgress(onProgress func(string)) func(int64) {
+	return func(bytes int64) {
+		onProgress(utils.FormatBinaryBytes(int(bytes)))
+	}
+}
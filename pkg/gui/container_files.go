@@ -0,0 +1,131 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+func (gui *Gui) renderContainerFiles(ctr *commands.Container) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			gui.RenderStringMain(gui.Tr.LoadingFiles)
+
+			entries, err := ctr.ListDir(ctx, "/")
+			if err != nil {
+				if ctx.Err() == nil {
+					gui.RenderStringMain(err.Error())
+				}
+				return
+			}
+
+			// the tab's task is stopped when the user moves on, which cancels
+			// anything we're still reading
+			listDir := func(path string) ([]commands.ArchiveEntry, error) {
+				return ctr.ListDir(ctx, path)
+			}
+			readFile := func(path string) ([]byte, bool, error) {
+				return ctr.ReadFile(ctx, path, filePreviewLimit)
+			}
+			copyOut := func(path string) error {
+				return gui.promptCopyFromContainerDestination(ctr, path)
+			}
+
+			gui.setMainList(ctx, gui.fileTreeList(newFileTree("/", entries), listDir, readFile, copyOut))
+		},
+	})
+}
+
+// fileTreeList returns a mainList for browsing a file tree. Pressing enter
+// expands a directory, using listDir to list it the first time, or previews a
+// file, using readFile to read it. If copyOut is not nil, it's called with the
+// selected path when the user wants to copy it to the host.
+func (gui *Gui) fileTreeList(
+	tree *fileTree,
+	listDir func(path string) ([]commands.ArchiveEntry, error),
+	readFile func(path string) ([]byte, bool, error),
+	copyOut func(path string) error,
+) *mainList {
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		dirs, files, size := tree.counts()
+		header := utils.ResolvePlaceholderString(gui.Tr.FilesSummary, map[string]string{
+			"dirs":  fmt.Sprint(dirs),
+			"files": fmt.Sprint(files),
+			"size":  utils.FormatBinaryBytes(int(size)),
+		})
+		return header, tree.render()
+	}
+
+	list.onPress = func(idx int) error {
+		node := tree.nodeAt(idx)
+		switch {
+		case node == nil:
+			return nil
+		case node.isDir() && !node.listed:
+			return gui.WithWaitingStatus(gui.Tr.ListingDirectoryStatus, func() error {
+				entries, err := listDir(node.path)
+				if err != nil {
+					return err
+				}
+
+				gui.Update(func() error {
+					node.setChildren(entries)
+					node.expanded = true
+					if gui.State.Panels.Main.List == list {
+						gui.renderMainList()
+					}
+					return nil
+				})
+				return nil
+			})
+		case node.isDir():
+			node.expanded = !node.expanded
+		case node.preview != nil:
+			node.preview = nil
+		case node.isRegular():
+			return gui.WithWaitingStatus(gui.Tr.LoadingFileStatus, func() error {
+				content, truncated, err := readFile(node.path)
+				if err != nil {
+					return err
+				}
+
+				gui.Update(func() error {
+					node.setPreview(content, truncated, gui.Tr)
+					if gui.State.Panels.Main.List == list {
+						gui.renderMainList()
+					}
+					return nil
+				})
+				return nil
+			})
+		default:
+			return nil
+		}
+
+		// collapsing a preview can leave the selection below the node it belonged to
+		list.selectedIdx = tree.lineOf(node)
+		gui.renderMainList()
+		return nil
+	}
+
+	if copyOut != nil {
+		list.keyHandlers = map[rune]func(int) error{
+			'C': func(idx int) error {
+				node := tree.nodeAt(idx)
+				if node == nil {
+					return nil
+				}
+				return copyOut(node.path)
+			},
+		}
+	}
+
+	return list
+}
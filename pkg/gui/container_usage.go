@@ -0,0 +1,76 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/presentation"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// containerUsageList returns a mainList of the given containers (e.g. the ones
+// using an image), where pressing enter on a container takes you to it.
+// extraCells, if not nil, returns more columns to show for each container.
+func (gui *Gui) containerUsageList(header string, containers []*commands.Container, extraCells func(*commands.Container) []string) *mainList {
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		rows := lo.Map(containers, func(ctr *commands.Container, _ int) []string {
+			// the status, substatus and name columns of the containers panel
+			cells := presentation.GetContainerDisplayStrings(gui.Tr, &gui.Config.UserConfig.Gui, ctr, nil)[:3]
+			cells = append(cells, ctr.Container.Status)
+			if extraCells != nil {
+				cells = append(cells, extraCells(ctr)...)
+			}
+			return cells
+		})
+
+		table, err := utils.RenderTable(rows)
+		if err != nil {
+			gui.Log.Error(err)
+			return header, nil
+		}
+
+		return header, strings.Split(table, "\n")
+	}
+
+	list.onPress = func(idx int) error {
+		return gui.selectContainer(containers[idx])
+	}
+
+	return list
+}
+
+// selectContainer switches to the containers panel and selects the given
+// container. In docker-compose mode a service's container only appears in
+// the services panel, so we select the service instead.
+func (gui *Gui) selectContainer(ctr *commands.Container) error {
+	if err := gui.switchToMode(MODE_CONTAINERS); err != nil {
+		return err
+	}
+
+	if _, idx, found := lo.FindIndexOf(gui.Panels.Containers.List.GetItems(), func(c *commands.Container) bool {
+		return c.ID == ctr.ID
+	}); found && gui.isPanelVisible(gui.Panels.Containers.View.Name()) {
+		gui.Panels.Containers.SetSelectedLineIdx(idx)
+		if err := gui.switchFocus(gui.Panels.Containers.View); err != nil {
+			return err
+		}
+		return gui.Panels.Containers.RerenderList()
+	}
+
+	if _, idx, found := lo.FindIndexOf(gui.Panels.Services.List.GetItems(), func(service *commands.Service) bool {
+		return service.Container != nil && service.Container.ID == ctr.ID
+	}); found && gui.isPanelVisible(gui.Panels.Services.View.Name()) {
+		gui.Panels.Services.SetSelectedLineIdx(idx)
+		if err := gui.switchFocus(gui.Panels.Services.View); err != nil {
+			return err
+		}
+		return gui.Panels.Services.RerenderList()
+	}
+
+	// e.g. the container belongs to another project, or we're hiding stopped
+	// containers
+	return gui.createErrorPanel(gui.Tr.ContainerNotShown)
+}
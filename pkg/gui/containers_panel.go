@@ -3,6 +3,7 @@ package gui
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -63,6 +64,16 @@ func (gui *Gui) getContainersPanel() *panels.SideListPanel[*commands.Container]
 						Title:  gui.Tr.TopTitle,
 						Render: gui.renderContainerTop,
 					},
+					{
+						Key:    "files",
+						Title:  gui.Tr.FilesTitle,
+						Render: gui.renderContainerFiles,
+					},
+					{
+						Key:    "diff",
+						Title:  gui.Tr.DiffTitle,
+						Render: gui.renderContainerDiff,
+					},
 				}
 			},
 			GetItemContextCacheKey: func(container *commands.Container) string {
@@ -107,11 +118,14 @@ func (gui *Gui) getContainersPanel() *panels.SideListPanel[*commands.Container]
 			return true
 		},
 		GetTableCells: func(container *commands.Container) []string {
-			return presentation.GetContainerDisplayStrings(&gui.Config.UserConfig.Gui, container)
+			return presentation.GetContainerDisplayStrings(gui.Tr, &gui.Config.UserConfig.Gui, container, gui.DockerCommand.ImageUpdate(container))
 		},
 		Hide: func() bool {
 			return gui.State.UIMode != MODE_CONTAINERS
 		},
+		GetItemKey: func(container *commands.Container) string {
+			return container.ID
+		},
 	}
 }
 
@@ -214,6 +228,11 @@ func (gui *Gui) containerConfigStr(container *commands.Container) string {
 		output += "none\n"
 	}
 
+	output += utils.WithPadding("Networks: ", padding)
+	output += gui.networkEndpointsStr(padding, gui.Tr.NetworkColumn, container.NetworkEndpoints(), func(endpoint *commands.NetworkEndpoint) string {
+		return endpoint.NetworkName
+	})
+
 	data, err := utils.MarshalIntoYaml(&container.Details)
 	if err != nil {
 		return fmt.Sprintf("Error marshalling container details: %v", err)
@@ -224,6 +243,36 @@ func (gui *Gui) containerConfigStr(container *commands.Container) string {
 	return output
 }
 
+func (gui *Gui) renderContainerDiff(container *commands.Container) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			changes, err := container.Diff(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					gui.RenderStringMain(err.Error())
+				}
+				return
+			}
+
+			if len(changes) == 0 {
+				gui.RenderStringMain(gui.Tr.NoFilesystemChanges)
+				return
+			}
+
+			added, changed, deleted := presentation.CountContainerDiff(changes)
+			summary := utils.ResolvePlaceholderString(gui.Tr.FilesystemChangesSummary, map[string]string{
+				"added":   utils.ColoredString(fmt.Sprint(added), color.FgGreen),
+				"changed": utils.ColoredString(fmt.Sprint(changed), color.FgYellow),
+				"deleted": utils.ColoredString(fmt.Sprint(deleted), color.FgRed),
+			})
+
+			gui.RenderStringMain(summary + "\n\n" + presentation.RenderContainerDiff(changes))
+		},
+	})
+}
+
 func (gui *Gui) renderContainerStats(container *commands.Container) tasks.TaskFunc {
 	return gui.NewTickerTask(TickerTaskOpts{
 		Func: func(ctx context.Context, notifyStopped chan struct{}) {
@@ -280,6 +329,15 @@ func (gui *Gui) refreshContainersAndServices() error {
 	gui.Panels.Services.SetItems(services)
 	gui.Panels.Containers.SetItems(containers)
 
+	// the images and volumes panels show how many containers use each image
+	// and volume
+	if err := gui.Panels.Images.RerenderList(); err != nil {
+		return err
+	}
+	if err := gui.Panels.Volumes.RerenderList(); err != nil {
+		return err
+	}
+
 	// see if our selected service has moved
 	if isServiceSelected {
 		for i, service := range gui.Panels.Services.List.GetItems() {
@@ -317,6 +375,10 @@ func (gui *Gui) handleHideStoppedContainers(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleContainersRemoveMenu(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Containers.MarkedItems(); len(marked) > 0 {
+		return gui.batchContainerRemoveMenu(marked)
+	}
+
 	ctr, err := gui.Panels.Containers.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -375,6 +437,10 @@ func (gui *Gui) PauseContainer(container *commands.Container) error {
 }
 
 func (gui *Gui) handleContainerPause(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Containers.MarkedItems(); len(marked) > 0 {
+		return gui.batchContainerPause(marked)
+	}
+
 	ctr, err := gui.Panels.Containers.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -384,6 +450,10 @@ func (gui *Gui) handleContainerPause(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleContainerStartStop(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Containers.MarkedItems(); len(marked) > 0 {
+		return gui.batchContainerStartStop(marked)
+	}
+
 	ctr, err := gui.Panels.Containers.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -411,6 +481,10 @@ func (gui *Gui) handleContainerStartStop(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleContainerRestart(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Containers.MarkedItems(); len(marked) > 0 {
+		return gui.batchContainerRestart(marked)
+	}
+
 	ctr, err := gui.Panels.Containers.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -431,24 +505,21 @@ func (gui *Gui) handleContainerAttach(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	c, err := ctr.Attach()
-	if err != nil {
+	return gui.attachToContainer(ctr)
+}
+
+func (gui *Gui) attachToContainer(ctr *commands.Container) error {
+	if err := ctr.CanAttach(); err != nil {
 		return gui.createErrorPanel(err.Error())
 	}
 
-	return gui.runSubprocessWithMessage(c, gui.Tr.DetachFromContainerShortCut)
-}
+	msg := utils.ResolvePlaceholderString(gui.Tr.DetachFromContainerShortCut, map[string]string{
+		"detachKeys": gui.Config.UserConfig.Attach.DetachKeys,
+	})
 
-func (gui *Gui) handlePruneContainers() error {
-	return gui.createConfirmationPanel(gui.Tr.Confirm, gui.Tr.ConfirmPruneContainers, func(g *gocui.Gui, v *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
-			err := gui.DockerCommand.PruneContainers()
-			if err != nil {
-				return gui.createErrorPanel(err.Error())
-			}
-			return nil
-		})
-	}, nil)
+	return gui.runInTerminalWithMessage(func(ctx context.Context) error {
+		return ctr.Attach(ctx, os.Stdin, os.Stdout, os.Stderr)
+	}, msg)
 }
 
 func (gui *Gui) handleContainerViewLogs(g *gocui.Gui, v *gocui.View) error {
@@ -505,6 +576,10 @@ func (gui *Gui) containerExecShell(container *commands.Container) error {
 }
 
 func (gui *Gui) handleContainersCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Containers.MarkedItems(); len(marked) > 0 {
+		return gui.batchContainersCustomCommand(marked)
+	}
+
 	ctr, err := gui.Panels.Containers.GetSelectedItem()
 	if err != nil {
 		return nil
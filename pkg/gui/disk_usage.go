@@ -0,0 +1,154 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+const diskUsageKey = "disk-usage"
+
+// handleDiskUsage shows how much disk space docker is using in the main view,
+// like `docker system df`, and focuses it so that the user can drill down into
+// each kind of object
+func (gui *Gui) handleDiskUsage(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	err := gui.loadMainList(diskUsageKey, gui.Tr.CalculatingDiskUsage, func(ctx context.Context) (*mainList, error) {
+		usage, err := gui.DockerCommand.DiskUsage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return gui.diskUsageList(usage), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return gui.focusMain()
+}
+
+// diskUsageList returns a mainList with a line for each kind of object.
+// Pressing enter on one lists the objects of that kind, largest first.
+func (gui *Gui) diskUsageList(usage *commands.DiskUsage) *mainList {
+	categories := []struct {
+		title string
+		*commands.DiskUsageCategory
+	}{
+		{gui.Tr.ImagesTitle, &usage.Images},
+		{gui.Tr.ContainersTitle, &usage.Containers},
+		{gui.Tr.VolumesTitle, &usage.Volumes},
+		{gui.Tr.BuildCacheTitle, &usage.BuildCache},
+	}
+
+	expanded := map[int]bool{}
+	// the index of the category on each line, or -1 for the lines listing a
+	// category's objects
+	lineCategories := []int{}
+
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		rows := [][]string{{
+			"  " + gui.Tr.TypeColumn,
+			gui.Tr.TotalColumn,
+			gui.Tr.ActiveColumn,
+			gui.Tr.SizeColumn,
+			gui.Tr.ReclaimableColumn,
+		}}
+		for i, category := range categories {
+			arrow := "▸"
+			if expanded[i] {
+				arrow = "▾"
+			}
+
+			rows = append(rows, []string{
+				arrow + " " + category.title,
+				fmt.Sprint(category.Count),
+				fmt.Sprint(category.Active),
+				utils.ColoredString(utils.FormatDecimalBytes(int(category.Size)), color.FgYellow),
+				formatReclaimable(category.Reclaimable, category.Size),
+			})
+		}
+
+		table, err := utils.RenderTable(rows)
+		if err != nil {
+			gui.Log.Error(err)
+			return "", nil
+		}
+		tableLines := strings.Split(table, "\n")
+
+		lines := []string{}
+		lineCategories = lineCategories[:0]
+		for i, category := range categories {
+			lines = append(lines, tableLines[i+1])
+			lineCategories = append(lineCategories, i)
+
+			if !expanded[i] {
+				continue
+			}
+			if len(category.Items) == 0 {
+				lines = append(lines, "    "+utils.ColoredString(gui.Tr.NoItems, color.FgBlue))
+				lineCategories = append(lineCategories, -1)
+				continue
+			}
+			for _, line := range renderDiskUsageItems(category.Items) {
+				lines = append(lines, "    "+line)
+				lineCategories = append(lineCategories, -1)
+			}
+		}
+
+		header := utils.ColoredString(gui.Tr.DiskUsageTitle, color.FgCyan) + "\n\n" + tableLines[0]
+		return header, lines
+	}
+
+	list.onPress = func(idx int) error {
+		if idx < 0 || idx >= len(lineCategories) || lineCategories[idx] == -1 {
+			return nil
+		}
+
+		expanded[lineCategories[idx]] = !expanded[lineCategories[idx]]
+		gui.renderMainList()
+		return nil
+	}
+
+	return list
+}
+
+// formatReclaimable shows the reclaimable bytes along with the proportion of
+// the total they make up, like `docker system df` does
+func formatReclaimable(reclaimable int64, total int64) string {
+	str := utils.FormatDecimalBytes(int(reclaimable))
+	if total > 0 {
+		str += fmt.Sprintf(" (%d%%)", reclaimable*100/total)
+	}
+
+	return utils.ColoredString(str, color.FgGreen)
+}
+
+func renderDiskUsageItems(items []commands.DiskUsageItem) []string {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		marker := utils.ColoredString("○", color.FgBlue)
+		if item.InUse {
+			marker = utils.ColoredString("●", color.FgGreen)
+		}
+
+		rows[i] = []string{
+			marker,
+			utils.ColoredString(utils.FormatDecimalBytes(int(item.Size)), color.FgYellow),
+			item.Name,
+			utils.ColoredString(item.Detail, color.FgMagenta),
+		}
+	}
+
+	table, _ := utils.RenderTable(rows)
+	return strings.Split(table, "\n")
+}
@@ -0,0 +1,193 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// we only preview files up to this size inline
+const filePreviewLimit = 64 * 1024
+
+// fileTree is an expandable tree of the files beneath some root path. We only
+// list a directory's entries once the user expands it, since listing a whole
+// container or volume at once means having docker send us everything in it.
+// It's rendered to the main view as a mainList.
+type fileTree struct {
+	rootPath string
+	root     *fileTreeNode
+
+	// the node each rendered line belongs to. A file being previewed takes up
+	// several lines.
+	lineNodes []*fileTreeNode
+}
+
+type fileTreeNode struct {
+	name     string
+	path     string
+	entry    commands.ArchiveEntry
+	depth    int
+	children []*fileTreeNode
+	expanded bool
+	// whether we've listed the directory's entries yet
+	listed bool
+
+	// the coloured lines of the file's contents, if we're previewing it
+	preview []string
+}
+
+// newFileTree returns a tree of the given root path, whose entries are those
+// directly inside it
+func newFileTree(rootPath string, entries []commands.ArchiveEntry) *fileTree {
+	root := &fileTreeNode{
+		name:     rootPath,
+		path:     rootPath,
+		entry:    commands.ArchiveEntry{Mode: os.ModeDir | 0o755},
+		expanded: true,
+	}
+	root.setChildren(entries)
+
+	return &fileTree{rootPath: rootPath, root: root}
+}
+
+// setChildren sets a directory's entries, each of whose paths is its name
+func (n *fileTreeNode) setChildren(entries []commands.ArchiveEntry) {
+	n.children = lo.Map(entries, func(entry commands.ArchiveEntry, _ int) *fileTreeNode {
+		return &fileTreeNode{
+			name:  entry.Path,
+			path:  path.Join(n.path, entry.Path),
+			entry: entry,
+			depth: n.depth + 1,
+		}
+	})
+	n.listed = true
+
+	sort.SliceStable(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if a.isDir() != b.isDir() {
+			return a.isDir()
+		}
+		return a.name < b.name
+	})
+}
+
+func (n *fileTreeNode) isDir() bool {
+	return n.entry.IsDir()
+}
+
+func (n *fileTreeNode) isRegular() bool {
+	return n.entry.Mode.IsRegular()
+}
+
+// counts returns the number of directories and files we've listed beneath the
+// root, along with the total size of the files
+func (t *fileTree) counts() (int, int, int64) {
+	dirs, files := 0, 0
+	var size int64
+
+	var walk func(node *fileTreeNode)
+	walk = func(node *fileTreeNode) {
+		for _, child := range node.children {
+			if child.isDir() {
+				dirs++
+				walk(child)
+			} else {
+				files++
+				size += child.entry.Size
+			}
+		}
+	}
+	walk(t.root)
+
+	return dirs, files, size
+}
+
+// render returns the tree's lines, recording which node each one belongs to
+func (t *fileTree) render() []string {
+	lines := []string{}
+	t.lineNodes = []*fileTreeNode{}
+
+	var walk func(node *fileTreeNode)
+	walk = func(node *fileTreeNode) {
+		lines = append(lines, node.displayString())
+		t.lineNodes = append(t.lineNodes, node)
+
+		for _, previewLine := range node.preview {
+			lines = append(lines, strings.Repeat("  ", node.depth+1)+utils.ColoredString("│ ", color.FgBlue)+previewLine)
+			t.lineNodes = append(t.lineNodes, node)
+		}
+
+		if !node.expanded {
+			return
+		}
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	walk(t.root)
+
+	return lines
+}
+
+func (n *fileTreeNode) displayString() string {
+	indent := strings.Repeat("  ", n.depth)
+
+	if n.isDir() {
+		arrow := "▸"
+		if n.expanded {
+			arrow = "▾"
+		}
+		name := n.name
+		if name != "/" {
+			name += "/"
+		}
+		return fmt.Sprintf("%s%s %s", indent, arrow, utils.ColoredString(name, color.FgBlue))
+	}
+
+	if n.entry.Mode&os.ModeSymlink != 0 {
+		return fmt.Sprintf("%s  %s -> %s", indent, utils.ColoredString(n.name, color.FgCyan), n.entry.LinkTarget)
+	}
+
+	return fmt.Sprintf("%s  %s %s", indent, n.name, utils.ColoredString(utils.FormatBinaryBytes(int(n.entry.Size)), color.FgYellow))
+}
+
+// nodeAt returns the node that the given rendered line belongs to
+func (t *fileTree) nodeAt(idx int) *fileTreeNode {
+	if idx < 0 || idx >= len(t.lineNodes) {
+		return nil
+	}
+	return t.lineNodes[idx]
+}
+
+// lineOf returns the first rendered line belonging to the given node
+func (t *fileTree) lineOf(node *fileTreeNode) int {
+	for i, lineNode := range t.lineNodes {
+		if lineNode == node {
+			return i
+		}
+	}
+	return 0
+}
+
+// setPreview sets the lines we show beneath a file when previewing it
+func (n *fileTreeNode) setPreview(content []byte, truncated bool, tr *i18n.TranslationSet) {
+	if bytes.IndexByte(content, 0) != -1 {
+		n.preview = []string{utils.ColoredString(tr.BinaryFilePreview, color.FgYellow)}
+		return
+	}
+
+	text := strings.TrimSuffix(utils.NormalizeLinefeeds(string(content)), "\n")
+	n.preview = strings.Split(utils.ColoredSourceString(n.name, text), "\n")
+	if truncated {
+		n.preview = append(n.preview, utils.ColoredString(tr.TruncatedFilePreview, color.FgYellow))
+	}
+}
@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"os"
+	"testing"
+
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTree(t *testing.T) {
+	dir := os.ModeDir | 0o755
+
+	tree := newFileTree("/etc", []commands.ArchiveEntry{
+		{Path: "hosts", Mode: 0o644, Size: 10},
+		{Path: "nginx", Mode: dir},
+	})
+
+	dirs, files, size := tree.counts()
+	assert.EqualValues(t, 1, dirs)
+	assert.EqualValues(t, 1, files)
+	assert.EqualValues(t, 10, size)
+
+	// directories come first, and we don't know what's in them until they're
+	// listed
+	lines := tree.render()
+	assert.Len(t, lines, 3)
+	assert.Contains(t, utils.Decolorise(lines[1]), "▸ nginx/")
+	assert.Contains(t, utils.Decolorise(lines[2]), "hosts")
+
+	nginx := tree.nodeAt(1)
+	assert.EqualValues(t, "/etc/nginx", nginx.path)
+	assert.False(t, nginx.listed)
+
+	nginx.setChildren([]commands.ArchiveEntry{
+		{Path: "nginx.conf", Mode: 0o644, Size: 20},
+		{Path: "conf.d", Mode: dir},
+	})
+	nginx.expanded = true
+
+	assert.Len(t, tree.render(), 5)
+	assert.EqualValues(t, "/etc/nginx/conf.d", tree.nodeAt(2).path)
+	assert.EqualValues(t, 2, tree.nodeAt(2).depth)
+	assert.EqualValues(t, "/etc/nginx/nginx.conf", tree.nodeAt(3).path)
+
+	dirs, files, size = tree.counts()
+	assert.EqualValues(t, 2, dirs)
+	assert.EqualValues(t, 2, files)
+	assert.EqualValues(t, 30, size)
+}
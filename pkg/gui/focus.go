@@ -21,7 +21,7 @@ func (gui *Gui) newLineFocused(v *gocui.View) error {
 	case "about":
 		return nil
 	case "main":
-		v.Highlight = false
+		v.Highlight = gui.State.Panels.Main.List != nil
 		return nil
 	case "filter":
 		return nil
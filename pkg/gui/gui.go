@@ -19,6 +19,7 @@ import (
 	"github.com/peauc/lazydocker-ng/pkg/gui/types"
 	"github.com/peauc/lazydocker-ng/pkg/i18n"
 	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
 	"github.com/sasha-s/go-deadlock"
 	"github.com/sirupsen/logrus"
 )
@@ -60,11 +61,16 @@ type Panels struct {
 type Mutexes struct {
 	SubprocessMutex deadlock.Mutex
 	ViewStackMutex  deadlock.Mutex
+	// guards guiState.LayerAnalyses, which the layers tab's task writes to
+	LayerAnalysesMutex deadlock.Mutex
 }
 
 type mainPanelState struct {
 	// ObjectKey tells us what context we are in. For example, if we are looking at the logs of a particular service in the services panel this key might be 'services-<service id>-logs'. The key is made so that if something changes which might require us to re-run the logs command or run a different command, the key will be different, and we'll then know to do whatever is required. Object key probably isn't the best name for this but Context is already used to refer to tabs. Maybe I should just call them tabs.
 	ObjectKey string
+	// List is set when the current context renders an interactive list to the
+	// main view (e.g. a container's files), and is nil otherwise
+	List *mainList
 }
 
 type panelStates struct {
@@ -101,6 +107,28 @@ type guiState struct {
 	// Docker Compose context
 	InDockerComposeMode         bool   // Runtime: are we in project mode or container-only mode
 	CurrentDockerComposeProject string // Runtime: which project is selected?
+
+	// the image pull that's in progress, if any
+	ImagePull *imageTransfer
+
+	// the image push that's in progress, if any
+	ImagePush *imageTransfer
+
+	// the image build that's in progress, or the last one we ran
+	ImageBuild *imageBuild
+
+	// which images the images panel shows
+	ImagesView imagesView
+
+	// the layer analyses we've done most recently, keyed by image ID
+	LayerAnalyses *utils.LRU[string, *commands.ImageLayerAnalysis]
+
+	// the order the vulnerabilities tab lists an image's vulnerabilities in
+	VulnerabilitySort commands.VulnerabilitySort
+
+	// the images the user has asked us to scan, which the vulnerabilities tab
+	// scans the next time it's rendered for them
+	VulnerabilityScanRequests map[string]bool
 }
 
 //type projectState struct {
@@ -169,6 +197,8 @@ func NewGui(log *logrus.Entry, dockerCommand *commands.DockerCommand, oSCommand
 			MODE_CONTAINERS: "",
 			MODE_RESSOURCES: "",
 		},
+		LayerAnalyses:             utils.NewLRU[string, *commands.ImageLayerAnalysis](layerAnalysesLimit),
+		VulnerabilityScanRequests: map[string]bool{},
 	}
 
 	gui := &Gui{
@@ -336,6 +366,13 @@ func (gui *Gui) Run() error {
 	go gui.listenForEvents(ctx, throttledRefresh.Trigger)
 	go gui.monitorContainerStats(ctx)
 
+	// a previous run may have crashed before removing its volume helpers
+	go func() {
+		if err := gui.DockerCommand.RemoveVolumeHelpers(ctx); err != nil {
+			gui.Log.Warn(err)
+		}
+	}()
+
 	go func() {
 		throttledRefresh.Trigger()
 
@@ -346,6 +383,12 @@ func (gui *Gui) Run() error {
 		gui.goEvery(time.Millisecond*1000, gui.renderContainersAndServices)
 	}()
 
+	if gui.Config.UserConfig.UpdateCheck.Enabled {
+		// checking takes a while, so we don't want to hold up the goroutine above.
+		// Each check only asks about images which are due to be checked again.
+		go gui.goEvery(time.Second*10, gui.checkImageUpdates)
+	}
+
 	err = g.MainLoop()
 	if err == gocui.ErrQuit {
 		return nil
@@ -481,6 +524,10 @@ func (gui *Gui) quit(g *gocui.Gui, v *gocui.View) error {
 // this handler is executed when we press escape when there is only one view
 // on the stack.
 func (gui *Gui) escape() error {
+	if panel, ok := gui.currentSidePanel(); ok && panel.ClearMarks() {
+		return panel.RerenderList()
+	}
+
 	if gui.State.Filter.active {
 		return gui.clearFilter()
 	}
@@ -517,6 +564,7 @@ func (gui *Gui) ShouldRefresh(key string) bool {
 	}
 
 	gui.State.Panels.Main.ObjectKey = key
+	gui.clearMainList()
 	return true
 }
 
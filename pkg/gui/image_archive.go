@@ -0,0 +1,132 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// characters we don't want in the archive filenames we suggest
+var archiveFilenameReplacer = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func (gui *Gui) handleContainerExport(g *gocui.Gui, v *gocui.View) error {
+	ctr, err := gui.Panels.Containers.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	return gui.promptArchivePath(gui.Tr.ExportContainerTitle, archiveFilename(ctr.Name), func(dstPath string) error {
+		return gui.WithProgressStatus(gui.Tr.ExportingStatus, func(onProgress func(string)) error {
+			return ctr.Export(dstPath, bytesProgress(onProgress))
+		})
+	})
+}
+
+func (gui *Gui) handleImagesArchiveMenu(g *gocui.Gui, v *gocui.View) error {
+	items := []*types.MenuItem{}
+
+	if marked := gui.Panels.Images.MarkedItems(); len(marked) > 0 {
+		items = append(items, &types.MenuItem{
+			Label: utils.ResolvePlaceholderString(gui.Tr.SaveMarkedImages, map[string]string{
+				"count": fmt.Sprint(len(marked)),
+			}),
+			OnPress: func() error {
+				return gui.promptSaveImages(marked, "images.tar")
+			},
+		})
+	} else if img, err := gui.Panels.Images.GetSelectedItem(); err == nil {
+		items = append(items, &types.MenuItem{
+			Label: gui.Tr.SaveImage,
+			OnPress: func() error {
+				return gui.promptSaveImages([]*commands.Image{img}, archiveFilename(img.Name+"_"+img.Tag))
+			},
+		})
+	}
+
+	if images := gui.Panels.Images.List.GetItems(); len(images) > 0 {
+		items = append(items, &types.MenuItem{
+			Label: utils.ResolvePlaceholderString(gui.Tr.SaveListedImages, map[string]string{
+				"count": fmt.Sprint(len(images)),
+			}),
+			OnPress: func() error {
+				return gui.promptSaveImages(images, "images.tar")
+			},
+		})
+	}
+
+	items = append(items, &types.MenuItem{
+		Label:   gui.Tr.LoadImages,
+		OnPress: gui.promptLoadImages,
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.SaveLoadImages,
+		Items: items,
+	})
+}
+
+func (gui *Gui) promptSaveImages(images []*commands.Image, initialPath string) error {
+	return gui.promptArchivePath(gui.Tr.SaveImagesTitle, initialPath, func(dstPath string) error {
+		return gui.WithProgressStatus(gui.Tr.SavingStatus, func(onProgress func(string)) error {
+			return gui.DockerCommand.SaveImages(images, dstPath, bytesProgress(onProgress))
+		})
+	})
+}
+
+func (gui *Gui) promptLoadImages() error {
+	return gui.createPromptPanel(gui.Tr.LoadImagesTitle, func(g *gocui.Gui, v *gocui.View) error {
+		srcPath := gui.trimmedContent(v)
+		if srcPath == "" {
+			return nil
+		}
+
+		return gui.WithProgressStatus(gui.Tr.LoadingImagesStatus, func(onProgress func(string)) error {
+			refs, err := gui.DockerCommand.LoadImages(srcPath, func(read int64, total int64) {
+				onProgress(fmt.Sprintf("%s/%s", utils.FormatBinaryBytes(int(read)), utils.FormatBinaryBytes(int(total))))
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(refs) == 0 {
+				return gui.reloadImages()
+			}
+
+			return gui.selectImageByRef(refs[0])
+		})
+	})
+}
+
+// promptArchivePath asks where to write an archive, suggesting initialPath,
+// and confirms before overwriting an existing file
+func (gui *Gui) promptArchivePath(title string, initialPath string, run func(dstPath string) error) error {
+	return gui.createPromptPanelWithInitialContent(title, initialPath, func(g *gocui.Gui, v *gocui.View) error {
+		dstPath := gui.trimmedContent(v)
+		if dstPath == "" {
+			return nil
+		}
+
+		if _, err := os.Stat(dstPath); err != nil {
+			return run(dstPath)
+		}
+
+		message := utils.ResolvePlaceholderString(gui.Tr.ConfirmCopyOverwrite, map[string]string{
+			"path": dstPath,
+		})
+		return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+			return run(dstPath)
+		}, nil)
+	})
+}
+
+// archiveFilename suggests a filename for an archive of the named container or
+// image
+func archiveFilename(name string) string {
+	return strings.Trim(archiveFilenameReplacer.ReplaceAllString(name, "_"), "_") + ".tar"
+}
@@ -0,0 +1,204 @@
+package gui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+)
+
+// the main view's key while it's showing the output of a build
+const imageBuildKey = "image-build"
+
+// imageBuild is the build that's in progress, or the last one we ran
+type imageBuild struct {
+	input    imageBuildInput
+	progress *buildProgress
+	cancel   context.CancelFunc
+}
+
+// imageBuildInput is what the user typed into the build prompts, which we use
+// to pre-fill them for the next build
+type imageBuildInput struct {
+	contextDir string
+	dockerfile string
+	tag        string
+	buildArgs  string
+	target     string
+	platform   string
+}
+
+func (gui *Gui) handleImagesBuild(g *gocui.Gui, v *gocui.View) error {
+	build := gui.State.ImageBuild
+	if build == nil {
+		return gui.promptBuildImage(imageBuildInput{contextDir: ".", dockerfile: "Dockerfile"})
+	}
+
+	showOutput := &types.MenuItem{
+		Label: gui.Tr.ShowBuildOutput,
+		OnPress: func() error {
+			return gui.showBuildProgress(build)
+		},
+	}
+
+	items := []*types.MenuItem{}
+	if build.progress.isFinished() {
+		items = append(items,
+			&types.MenuItem{
+				Label:   gui.Tr.BuildImage,
+				OnPress: func() error { return gui.promptBuildImage(build.input) },
+			},
+			showOutput,
+		)
+	} else {
+		items = append(items,
+			showOutput,
+			&types.MenuItem{
+				Label: gui.Tr.CancelBuild,
+				OnPress: func() error {
+					build.cancel()
+					return nil
+				},
+			},
+		)
+	}
+
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.BuildImage,
+		Items: items,
+	})
+}
+
+// promptBuildImage asks for each of the build's options in turn, pre-filling
+// the prompts with the given input, and then starts the build
+func (gui *Gui) promptBuildImage(input imageBuildInput) error {
+	return gui.createPromptPanelWithInitialContent(gui.Tr.BuildContextTitle, input.contextDir, func(g *gocui.Gui, v *gocui.View) error {
+		input.contextDir = gui.trimmedContent(v)
+		if input.contextDir == "" {
+			return nil
+		}
+		if input.tag == "" {
+			if absContextDir, err := filepath.Abs(input.contextDir); err == nil {
+				input.tag = strings.ToLower(filepath.Base(absContextDir)) + ":latest"
+			}
+		}
+
+		return gui.createPromptPanelWithInitialContent(gui.Tr.BuildDockerfileTitle, input.dockerfile, func(g *gocui.Gui, v *gocui.View) error {
+			input.dockerfile = gui.trimmedContent(v)
+
+			return gui.createPromptPanelWithInitialContent(gui.Tr.BuildTagTitle, input.tag, func(g *gocui.Gui, v *gocui.View) error {
+				input.tag = gui.trimmedContent(v)
+
+				return gui.createPromptPanelWithInitialContent(gui.Tr.BuildArgsTitle, input.buildArgs, func(g *gocui.Gui, v *gocui.View) error {
+					input.buildArgs = gui.trimmedContent(v)
+
+					return gui.createPromptPanelWithInitialContent(gui.Tr.BuildTargetTitle, input.target, func(g *gocui.Gui, v *gocui.View) error {
+						input.target = gui.trimmedContent(v)
+
+						return gui.createPromptPanelWithInitialContent(gui.Tr.BuildPlatformTitle, input.platform, func(g *gocui.Gui, v *gocui.View) error {
+							input.platform = gui.trimmedContent(v)
+
+							return gui.buildImage(input)
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+// buildImage builds an image in the background, showing its output in the main
+// view. If the build succeeds we select the new image in the images panel, and
+// if it fails we scroll to the step that failed.
+func (gui *Gui) buildImage(input imageBuildInput) error {
+	buildArgs, err := commands.ParseBuildArgs(input.buildArgs)
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	tag := input.tag
+	if tag != "" {
+		if tag, err = commands.NormaliseImageReference(tag); err != nil {
+			return gui.createErrorPanel(err.Error())
+		}
+	}
+
+	dockerfile := input.dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	opts := commands.BuildImageOptions{
+		ContextDir: input.contextDir,
+		Dockerfile: dockerfile,
+		Tag:        tag,
+		BuildArgs:  buildArgs,
+		Target:     input.target,
+		Platform:   input.platform,
+	}
+
+	title := input.contextDir + " (" + dockerfile + ")"
+	if tag != "" {
+		title += " -> " + tag
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	build := &imageBuild{
+		input:    input,
+		progress: newBuildProgress(gui.Tr.BuildingImage + " " + title),
+		cancel:   cancel,
+	}
+	gui.State.ImageBuild = build
+
+	if err := gui.showBuildProgress(build); err != nil {
+		return err
+	}
+
+	return gui.WithProgressStatus(gui.Tr.BuildingStatus, func(onProgress func(string)) error {
+		defer cancel()
+
+		imageID, err := gui.DockerCommand.BuildImage(ctx, opts, func(event commands.BuildEvent) {
+			build.progress.update(event)
+			onProgress(build.progress.summary())
+		})
+		if ctx.Err() != nil {
+			build.progress.finish(gui.Tr.BuildCancelled, true)
+			return nil
+		}
+		if err != nil {
+			// rather than showing an error panel we bring back the build's output
+			// if the user has moved on, so that they can see where it went wrong
+			build.progress.finish(err.Error(), true)
+			gui.Update(func() error {
+				if gui.State.Panels.Main.ObjectKey == imageBuildKey {
+					return nil
+				}
+				return gui.showBuildProgress(build)
+			})
+			return nil
+		}
+
+		build.progress.finish(gui.Tr.BuildSucceeded, false)
+
+		switch {
+		case imageID != "":
+			return gui.selectImage(imageID)
+		case tag != "":
+			return gui.selectImageByRef(tag)
+		default:
+			return gui.reloadImages()
+		}
+	})
+}
+
+func (gui *Gui) showBuildProgress(build *imageBuild) error {
+	return gui.showProgressInMain(imageBuildKey, build.progress, true, func(v *gocui.View) {
+		if line, failed := build.progress.getFailureLine(); failed {
+			v.Autoscroll = false
+			v.SetOrigin(0, line)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// the number of files we list in the wasted space section of the layers tab
+const wastedFilesLimit = 20
+
+// the number of layer analyses we hang on to. An analysis lists every file in
+// the image, so they can be big.
+const layerAnalysesLimit = 10
+
+func (gui *Gui) renderImageLayers(img *commands.Image) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			gui.Mutexes.LayerAnalysesMutex.Lock()
+			analysis, ok := gui.State.LayerAnalyses.Get(img.ID)
+			gui.Mutexes.LayerAnalysesMutex.Unlock()
+
+			if !ok {
+				gui.RenderStringMain(gui.Tr.AnalysingLayers)
+
+				var err error
+				analysis, err = img.AnalyseLayers(ctx)
+				if err != nil {
+					if ctx.Err() == nil {
+						gui.RenderStringMain(err.Error())
+					}
+					return
+				}
+
+				// image IDs are content hashes, so the analysis never goes stale
+				gui.Mutexes.LayerAnalysesMutex.Lock()
+				gui.State.LayerAnalyses.Add(img.ID, analysis)
+				gui.Mutexes.LayerAnalysesMutex.Unlock()
+			}
+
+			gui.setMainList(ctx, gui.imageLayersList(analysis))
+		},
+	})
+}
+
+// imageLayersList returns a mainList showing each step of an image's history.
+// Pressing enter on a step that created a layer lists the files it added,
+// changed and removed. Below the steps we list the files wasting the most space.
+func (gui *Gui) imageLayersList(analysis *commands.ImageLayerAnalysis) *mainList {
+	expanded := map[int]bool{}
+	// the index of the layer on each line, or -1 for lines that aren't layers
+	lineLayers := []int{}
+
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		lines := []string{}
+		lineLayers = lineLayers[:0]
+
+		for i, layer := range analysis.Layers {
+			lines = append(lines, renderImageLayerLine(layer, expanded[i]))
+			lineLayers = append(lineLayers, i)
+
+			if !expanded[i] {
+				continue
+			}
+			for _, change := range layer.Changes {
+				lines = append(lines, "    "+renderLayerChange(change))
+				lineLayers = append(lineLayers, -1)
+			}
+		}
+
+		if len(analysis.WastedFiles) > 0 {
+			lines = append(lines, "", utils.ColoredString(gui.Tr.WastedSpaceTitle, color.FgCyan))
+			lineLayers = append(lineLayers, -1, -1)
+
+			for i, file := range analysis.WastedFiles {
+				if i == wastedFilesLimit {
+					lines = append(lines, utils.ResolvePlaceholderString(gui.Tr.AndMore, map[string]string{
+						"count": fmt.Sprint(len(analysis.WastedFiles) - wastedFilesLimit),
+					}))
+					lineLayers = append(lineLayers, -1)
+					break
+				}
+
+				lines = append(lines, fmt.Sprintf("  %s %s %s",
+					utils.ColoredString(fmt.Sprintf("%10s", utils.FormatBinaryBytes(int(file.Size))), color.FgYellow),
+					utils.ColoredString(fmt.Sprintf("%3dx", file.Count), color.FgBlue),
+					file.Path,
+				))
+				lineLayers = append(lineLayers, -1)
+			}
+		}
+
+		return gui.imageLayersHeader(analysis), lines
+	}
+
+	list.onPress = func(idx int) error {
+		if idx < 0 || idx >= len(lineLayers) || lineLayers[idx] == -1 {
+			return nil
+		}
+
+		layerIdx := lineLayers[idx]
+		if analysis.Layers[layerIdx].Empty {
+			return nil
+		}
+
+		expanded[layerIdx] = !expanded[layerIdx]
+		gui.renderMainList()
+		return nil
+	}
+
+	return list
+}
+
+func (gui *Gui) imageLayersHeader(analysis *commands.ImageLayerAnalysis) string {
+	efficiency := analysis.Efficiency()
+
+	colour := color.FgGreen
+	switch {
+	case efficiency < 0.75:
+		colour = color.FgRed
+	case efficiency < 0.9:
+		colour = color.FgYellow
+	}
+
+	return utils.ResolvePlaceholderString(gui.Tr.LayersSummary, map[string]string{
+		"efficiency": utils.ColoredString(fmt.Sprintf("%.1f%%", efficiency*100), colour),
+		"wasted":     utils.FormatBinaryBytes(int(analysis.WastedSize)),
+		"total":      utils.FormatBinaryBytes(int(analysis.TotalSize)),
+	})
+}
+
+func renderImageLayerLine(layer commands.ImageLayer, expanded bool) string {
+	createdBy := strings.TrimSpace(strings.TrimPrefix(layer.CreatedBy, "/bin/sh -c #(nop) "))
+	createdBy = strings.ReplaceAll(createdBy, "\t", " ")
+
+	if layer.Empty {
+		return utils.ColoredString(fmt.Sprintf("  %10s  %s", "-", createdBy), color.FgBlue)
+	}
+
+	arrow := "▸"
+	if expanded {
+		arrow = "▾"
+	}
+
+	added, changed, deleted := 0, 0, 0
+	for _, change := range layer.Changes {
+		switch change.Kind {
+		case container.ChangeAdd:
+			added++
+		case container.ChangeModify:
+			changed++
+		case container.ChangeDelete:
+			deleted++
+		}
+	}
+
+	return fmt.Sprintf("%s %s  %s %s",
+		arrow,
+		utils.ColoredString(fmt.Sprintf("%10s", utils.FormatBinaryBytes(int(layer.Size))), color.FgYellow),
+		createdBy,
+		utils.ColoredString(fmt.Sprintf("+%d ~%d -%d", added, changed, deleted), color.FgBlue),
+	)
+}
+
+func renderLayerChange(change commands.LayerChange) string {
+	colour, marker := color.FgYellow, "C"
+	switch change.Kind {
+	case container.ChangeAdd:
+		colour, marker = color.FgGreen, "A"
+	case container.ChangeDelete:
+		colour, marker = color.FgRed, "D"
+	}
+
+	return utils.ColoredString(fmt.Sprintf("%s %s", marker, change.Path), colour) + " " + utils.FormatBinaryBytes(int(change.Size))
+}
@@ -0,0 +1,66 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageLayersList(t *testing.T) {
+	tr := i18n.GetTranslationSets()["en"]
+	gui := &Gui{Tr: &tr, State: guiState{Panels: &panelStates{Main: &mainPanelState{}}}}
+
+	analysis := &commands.ImageLayerAnalysis{
+		Layers: []commands.ImageLayer{
+			{CreatedBy: "ADD rootfs.tar /", Size: 15, Changes: []commands.LayerChange{
+				{Path: "/etc/hosts", Size: 10, Kind: container.ChangeAdd},
+				{Path: "/tmp/cache", Size: 5, Kind: container.ChangeAdd},
+			}},
+			{CreatedBy: "/bin/sh -c #(nop)  ENV FOO=bar", Empty: true},
+			{CreatedBy: "RUN rm /tmp/cache", Changes: []commands.LayerChange{
+				{Path: "/tmp/cache", Size: 5, Kind: container.ChangeDelete},
+			}},
+		},
+		TotalSize:   15,
+		WastedSize:  5,
+		WastedFiles: []commands.WastedFile{{Path: "/tmp/cache", Count: 2, Size: 5}},
+	}
+
+	list := gui.imageLayersList(analysis)
+
+	header, lines := list.render()
+	assert.Contains(t, utils.Decolorise(header), "Efficiency: 66.7%")
+	assert.EqualValues(t, []string{
+		"▸     15.00B  ADD rootfs.tar / +2 ~0 -0",
+		"           -  ENV FOO=bar",
+		"▸         0B  RUN rm /tmp/cache +0 ~0 -1",
+		"",
+		"Files overwritten or deleted by later layers",
+		"       5.00B   2x /tmp/cache",
+	}, decoloriseAll(lines))
+
+	// pressing enter on an empty layer does nothing
+	assert.NoError(t, list.onPress(1))
+	_, lines = list.render()
+	assert.Len(t, lines, 6)
+
+	assert.NoError(t, list.onPress(0))
+	_, lines = list.render()
+	assert.EqualValues(t, []string{
+		"▾     15.00B  ADD rootfs.tar / +2 ~0 -0",
+		"    A /etc/hosts 10.00B",
+		"    A /tmp/cache 5.00B",
+	}, decoloriseAll(lines[:3]))
+}
+
+func decoloriseAll(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = utils.Decolorise(line)
+	}
+	return result
+}
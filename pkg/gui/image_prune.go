@@ -0,0 +1,158 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// the most images we list when previewing a prune
+const imagePrunePreviewLimit = 20
+
+type imagesView int
+
+const (
+	imagesViewAll imagesView = iota
+	imagesViewDangling
+	// images which no container uses, dangling or not
+	imagesViewUnused
+)
+
+func (gui *Gui) handleImagesToggleView(g *gocui.Gui, v *gocui.View) error {
+	gui.State.ImagesView = (gui.State.ImagesView + 1) % 3
+
+	switch gui.State.ImagesView {
+	case imagesViewDangling:
+		gui.Views.Images.Title = gui.Tr.ImagesTitle + " (" + gui.Tr.DanglingImagesView + ")"
+	case imagesViewUnused:
+		gui.Views.Images.Title = gui.Tr.ImagesTitle + " (" + gui.Tr.UnusedImagesView + ")"
+	default:
+		gui.Views.Images.Title = gui.Tr.ImagesTitle
+	}
+
+	gui.Panels.Images.SetSelectedLineIdx(0)
+	return gui.Panels.Images.RerenderList()
+}
+
+func (gui *Gui) handlePruneImages() error {
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.PruneImages,
+		Items: []*types.MenuItem{
+			{
+				Label:   gui.Tr.PruneDanglingImages,
+				OnPress: func() error { return gui.promptImagePruneFilters(commands.ImagePruneOptions{}) },
+			},
+			{
+				Label:   gui.Tr.PruneUnusedImages,
+				OnPress: func() error { return gui.promptImagePruneFilters(commands.ImagePruneOptions{All: true}) },
+			},
+		},
+	})
+}
+
+// promptImagePruneFilters asks which images to prune by age and label before
+// previewing the prune
+func (gui *Gui) promptImagePruneFilters(options commands.ImagePruneOptions) error {
+	return gui.createPromptPanel(gui.Tr.PruneUntilTitle, func(g *gocui.Gui, v *gocui.View) error {
+		options.Until = gui.trimmedContent(v)
+		if options.Until != "" {
+			if _, err := commands.ParseUntil(options.Until, time.Now()); err != nil {
+				return gui.createErrorPanel(err.Error())
+			}
+		}
+
+		return gui.createPromptPanel(gui.Tr.PruneLabelsTitle, func(g *gocui.Gui, v *gocui.View) error {
+			options.Labels = strings.Fields(gui.trimmedContent(v))
+
+			return gui.previewImagePrune(options)
+		})
+	})
+}
+
+// previewImagePrune lists the images a prune would remove, and how much space
+// it would reclaim, asking for confirmation before pruning
+func (gui *Gui) previewImagePrune(options commands.ImagePruneOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.PreparingPruneStatus, func() error {
+		preview, err := gui.DockerCommand.PreviewImagePrune(
+			gui.Panels.Images.List.GetAllItems(),
+			gui.Panels.Containers.List.GetAllItems(),
+			options,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(preview.Images) == 0 {
+			return gui.createConfirmationPanel(gui.Tr.PruneImages, gui.Tr.NothingToPrune, nil, nil)
+		}
+
+		return gui.createConfirmationPanel(gui.Tr.Confirm, gui.imagePrunePreviewStr(preview), func(g *gocui.Gui, v *gocui.View) error {
+			return gui.pruneImages(options)
+		}, nil)
+	})
+}
+
+func (gui *Gui) imagePrunePreviewStr(preview *commands.ImagePrunePreview) string {
+	rows := [][]string{}
+	for i, img := range preview.Images {
+		if i == imagePrunePreviewLimit {
+			break
+		}
+
+		name := utils.ColoredString(utils.SafeTruncate(strings.TrimPrefix(img.ID, "sha256:"), 10), color.FgBlue)
+		if tags := img.RepoTags(); len(tags) > 0 {
+			name = strings.Join(tags, ", ")
+		}
+		rows = append(rows, []string{name, utils.ColoredString(utils.FormatDecimalBytes(int(img.Image.Size)), color.FgYellow)})
+	}
+
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+	if len(preview.Images) > imagePrunePreviewLimit {
+		table += "\n" + utils.ResolvePlaceholderString(gui.Tr.AndMore, map[string]string{
+			"count": fmt.Sprint(len(preview.Images) - imagePrunePreviewLimit),
+		})
+	}
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.ImagePrunePreview, map[string]string{
+		"count":  fmt.Sprint(len(preview.Images)),
+		"unique": utils.FormatDecimalBytes(int(preview.UniqueSize)),
+		"total":  utils.FormatDecimalBytes(int(preview.TotalSize)),
+	})
+
+	return summary + "\n\n" + table
+}
+
+func (gui *Gui) pruneImages(options commands.ImagePruneOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		report, err := gui.DockerCommand.PruneImages(options)
+		if err != nil {
+			return err
+		}
+
+		if err := gui.reloadImages(); err != nil {
+			return err
+		}
+
+		// untagging an image also gets an entry in the report, so we only count
+		// the images that were actually deleted
+		deleted := lo.CountBy(report.ImagesDeleted, func(item image.DeleteResponse) bool {
+			return item.Deleted != ""
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.PruneImages, utils.ResolvePlaceholderString(gui.Tr.ImagePruneReport, map[string]string{
+			"count": fmt.Sprint(deleted),
+			"size":  utils.FormatDecimalBytes(int(report.SpaceReclaimed)),
+		}), nil, nil)
+	})
+}
@@ -0,0 +1,135 @@
+package gui
+
+import (
+	"context"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// imageTransfer is a pull or push that's in progress
+type imageTransfer struct {
+	ref      string
+	progress *layerProgress
+	cancel   context.CancelFunc
+}
+
+// the main view's key while it's showing the progress of a pull or push
+func (t *imageTransfer) key() string {
+	return "image-transfer-" + t.ref
+}
+
+func (gui *Gui) handleImagesPullMenu(g *gocui.Gui, v *gocui.View) error {
+	items := []*types.MenuItem{}
+
+	if pull := gui.State.ImagePull; pull != nil {
+		items = gui.imageTransferMenuItems(pull, gui.Tr.ShowPullProgress, gui.Tr.CancelPull)
+	} else {
+		items = append(items, &types.MenuItem{
+			Label:   gui.Tr.PullImageByReference,
+			OnPress: gui.promptPullImage,
+		})
+
+		if img, err := gui.Panels.Images.GetSelectedItem(); err == nil {
+			for _, ref := range img.RepoTags() {
+				ref := ref
+				items = append(items, &types.MenuItem{
+					Label:   utils.ResolvePlaceholderString(gui.Tr.RepullImage, map[string]string{"ref": ref}),
+					OnPress: func() error { return gui.pullImage(ref) },
+				})
+			}
+		}
+	}
+
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.PullImage,
+		Items: items,
+	})
+}
+
+// imageTransferMenuItems returns the menu items for showing the progress of a
+// pull or push, and for cancelling it
+func (gui *Gui) imageTransferMenuItems(transfer *imageTransfer, showLabel string, cancelLabel string) []*types.MenuItem {
+	return []*types.MenuItem{
+		{
+			Label: utils.ResolvePlaceholderString(showLabel, map[string]string{"ref": transfer.ref}),
+			OnPress: func() error {
+				return gui.showProgressInMain(transfer.key(), transfer.progress, false, nil)
+			},
+		},
+		{
+			Label: utils.ResolvePlaceholderString(cancelLabel, map[string]string{"ref": transfer.ref}),
+			OnPress: func() error {
+				transfer.cancel()
+				return nil
+			},
+		},
+	}
+}
+
+func (gui *Gui) promptPullImage() error {
+	return gui.createPromptPanel(gui.Tr.PullImageTitle, func(g *gocui.Gui, v *gocui.View) error {
+		ref := gui.trimmedContent(v)
+		if ref == "" {
+			return nil
+		}
+
+		return gui.pullImage(ref)
+	})
+}
+
+// pullImage pulls an image in the background, showing the progress of each
+// layer in the main view. Once it's pulled we select it in the images panel.
+func (gui *Gui) pullImage(ref string) error {
+	return gui.pullImageThen(ref, gui.selectImageByRef)
+}
+
+// pullImageThen is like pullImage except that once the image is pulled we call
+// then with its normalised reference, rather than selecting it
+func (gui *Gui) pullImageThen(ref string, then func(ref string) error) error {
+	ref, err := commands.NormaliseImageReference(ref)
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pull := &imageTransfer{
+		ref:      ref,
+		progress: newLayerProgress(utils.ResolvePlaceholderString(gui.Tr.PullingImage, map[string]string{"ref": ref})),
+		cancel:   cancel,
+	}
+	gui.State.ImagePull = pull
+
+	if err := gui.showProgressInMain(pull.key(), pull.progress, false, nil); err != nil {
+		return err
+	}
+
+	return gui.WithProgressStatus(gui.Tr.PullingStatus, func(onProgress func(string)) error {
+		defer gui.Update(func() error {
+			if gui.State.ImagePull == pull {
+				gui.State.ImagePull = nil
+			}
+			return nil
+		})
+		defer cancel()
+
+		err := gui.DockerCommand.PullImage(ctx, ref, func(message jsonmessage.JSONMessage) {
+			pull.progress.update(message)
+			onProgress(pull.progress.summary())
+		})
+		if ctx.Err() != nil {
+			pull.progress.finish(gui.Tr.PullCancelled)
+			return nil
+		}
+		if err != nil {
+			pull.progress.finish(err.Error())
+			return err
+		}
+
+		pull.progress.finish("")
+		return then(ref)
+	})
+}
@@ -0,0 +1,191 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+func (gui *Gui) handleImagesTagsMenu(g *gocui.Gui, v *gocui.View) error {
+	img, err := gui.Panels.Images.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	items := []*types.MenuItem{
+		{
+			Label:   gui.Tr.AddTag,
+			OnPress: func() error { return gui.promptAddTag(img) },
+		},
+	}
+
+	tags := img.RepoTags()
+	for _, tag := range tags {
+		tag := tag
+		items = append(items, &types.MenuItem{
+			Label:   utils.ResolvePlaceholderString(gui.Tr.RemoveTag, map[string]string{"ref": tag}),
+			OnPress: func() error { return gui.removeTag(img, tag, len(tags) == 1) },
+		})
+	}
+
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.ManageTags,
+		Items: items,
+	})
+}
+
+func (gui *Gui) promptAddTag(img *commands.Image) error {
+	// the image's name may have had a prefix replaced for display, so we go by
+	// its actual tag
+	initialContent := ""
+	if tags := img.RepoTags(); len(tags) > 0 {
+		if repository, err := commands.ImageRepository(tags[0]); err == nil {
+			initialContent = repository + ":"
+		}
+	}
+
+	return gui.createPromptPanelWithInitialContent(gui.Tr.AddTagTitle, initialContent, func(g *gocui.Gui, v *gocui.View) error {
+		ref, err := commands.NormaliseImageReference(gui.trimmedContent(v))
+		if err != nil {
+			return gui.createErrorPanel(err.Error())
+		}
+
+		if err := img.AddTag(ref); err != nil {
+			return gui.createErrorPanel(err.Error())
+		}
+
+		return gui.reloadImages()
+	})
+}
+
+func (gui *Gui) removeTag(img *commands.Image, ref string, isLastTag bool) error {
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmRemoveTag, map[string]string{"ref": ref})
+	if isLastTag {
+		message += "\n\n" + gui.Tr.RemoveLastTagWarning
+	}
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.WithWaitingStatus(gui.Tr.RemovingStatus, func() error {
+			if err := img.RemoveTag(ref); err != nil {
+				return err
+			}
+
+			return gui.reloadImages()
+		})
+	}, nil)
+}
+
+func (gui *Gui) handleImagesPushMenu(g *gocui.Gui, v *gocui.View) error {
+	if push := gui.State.ImagePush; push != nil {
+		return gui.Menu(CreateMenuOptions{
+			Title: gui.Tr.PushImage,
+			Items: gui.imageTransferMenuItems(push, gui.Tr.ShowPushProgress, gui.Tr.CancelPush),
+		})
+	}
+
+	img, err := gui.Panels.Images.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	tags := img.RepoTags()
+	if len(tags) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoTagsToPush)
+	}
+
+	items := []*types.MenuItem{}
+	for _, tag := range tags {
+		tag := tag
+		items = append(items, &types.MenuItem{
+			Label:   utils.ResolvePlaceholderString(gui.Tr.PushTag, map[string]string{"ref": tag}),
+			OnPress: func() error { return gui.pushImage(tag) },
+		})
+	}
+
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.PushImage,
+		Items: items,
+	})
+}
+
+// pushImage pushes an image in the background, showing the progress of each
+// layer in the main view
+func (gui *Gui) pushImage(ref string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	push := &imageTransfer{
+		ref:      ref,
+		progress: newLayerProgress(utils.ResolvePlaceholderString(gui.Tr.PushingImage, map[string]string{"ref": ref})),
+		cancel:   cancel,
+	}
+	gui.State.ImagePush = push
+
+	if err := gui.showProgressInMain(push.key(), push.progress, false, nil); err != nil {
+		return err
+	}
+
+	return gui.WithProgressStatus(gui.Tr.PushingStatus, func(onProgress func(string)) error {
+		defer gui.Update(func() error {
+			if gui.State.ImagePush == push {
+				gui.State.ImagePush = nil
+			}
+			return nil
+		})
+		defer cancel()
+
+		err := gui.DockerCommand.PushImage(ctx, ref, func(message jsonmessage.JSONMessage) {
+			push.progress.update(message)
+			onProgress(push.progress.summary())
+		})
+		if ctx.Err() != nil {
+			push.progress.finish(gui.Tr.PushCancelled)
+			return nil
+		}
+		if err != nil {
+			push.progress.finish(err.Error())
+			return err
+		}
+
+		push.progress.finish(gui.Tr.PushSucceeded)
+		// pushing gives the image a repo digest
+		return gui.reloadImages()
+	})
+}
+
+func (gui *Gui) renderImageTags(img *commands.Image) tasks.TaskFunc {
+	return gui.NewSimpleRenderStringTask(func() string { return gui.imageTagsStr(img) })
+}
+
+// imageTagsStr lists an image's tags, each with the digest it has in its
+// repository if it's been pushed or pulled
+func (gui *Gui) imageTagsStr(img *commands.Image) string {
+	tags := img.RepoTags()
+	if len(tags) == 0 {
+		return gui.Tr.NoTags
+	}
+
+	lines := []string{}
+	for _, tag := range tags {
+		line := utils.ColoredString(tag, color.FgGreen)
+
+		repo := tag[:strings.LastIndex(tag, ":")]
+		for _, digest := range img.Image.RepoDigests {
+			if strings.HasPrefix(digest, repo+"@") {
+				line += " " + utils.ColoredString(strings.TrimPrefix(digest, repo+"@"), color.FgYellow)
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return fmt.Sprintf("%s\n\n%s", utils.ResolvePlaceholderString(gui.Tr.TagsSummary, map[string]string{
+		"count": fmt.Sprint(len(tags)),
+	}), strings.Join(lines, "\n"))
+}
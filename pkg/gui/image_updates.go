@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"context"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// checkImageUpdates asks the registries whether there are newer versions of
+// the running containers' images. The containers and services panels mark
+// those with updates the next time they're rendered.
+func (gui *Gui) checkImageUpdates() error {
+	running := lo.Filter(gui.Panels.Containers.List.GetAllItems(), func(ctr *commands.Container, _ int) bool {
+		return ctr.Container.State == "running"
+	})
+
+	gui.DockerCommand.CheckImageUpdates(context.Background(), running)
+	return nil
+}
+
+func (gui *Gui) handleContainerPullAndRecreate(g *gocui.Gui, v *gocui.View) error {
+	ctr, err := gui.Panels.Containers.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	return gui.pullAndRecreate(ctr.Name, ctr, func() error {
+		return ctr.Recreate(context.Background())
+	})
+}
+
+func (gui *Gui) handleServicePullAndRecreate(g *gocui.Gui, v *gocui.View) error {
+	service, err := gui.Panels.Services.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	if service.Container == nil {
+		return gui.createErrorPanel(gui.Tr.NoContainer)
+	}
+
+	recreateCommand := utils.ApplyTemplate(
+		gui.Config.UserConfig.CommandTemplates.RecreateService,
+		gui.DockerCommand.NewCommandObject(commands.CommandObject{Service: service}),
+	)
+
+	return gui.pullAndRecreate(service.Name, service.Container, func() error {
+		return gui.OSCommand.RunCommand(recreateCommand)
+	})
+}
+
+// pullAndRecreate pulls the tag the container was created from and then
+// recreates it (or its service) so that it uses the newly pulled image
+func (gui *Gui) pullAndRecreate(name string, ctr *commands.Container, recreate func() error) error {
+	ref, ok := commands.UpdateCheckRef(ctr.Container.Image)
+	if !ok {
+		return gui.createErrorPanel(gui.Tr.NoImageTagToPull)
+	}
+
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmPullAndRecreate, map[string]string{
+		"ref":  ref,
+		"name": name,
+	})
+	if update := gui.DockerCommand.ImageUpdate(ctr); update != nil && update.Available {
+		message = utils.ResolvePlaceholderString(gui.Tr.ImageUpdateAvailable, map[string]string{
+			"ref":    ref,
+			"digest": update.RemoteDigest,
+		}) + "\n\n" + message
+	}
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.pullImageThen(ref, func(string) error {
+			return gui.WithWaitingStatus(gui.Tr.RecreatingStatus, func() error {
+				if err := recreate(); err != nil {
+					return err
+				}
+				return gui.refreshContainersAndServices()
+			})
+		})
+	}, nil)
+}
@@ -0,0 +1,170 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/panels"
+	"github.com/peauc/lazydocker-ng/pkg/gui/presentation"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// renderImageVulnerabilities lists the vulnerabilities we found when we last
+// scanned the image. Scanning can take a while, so we only scan an image when
+// the user asks us to.
+func (gui *Gui) renderImageVulnerabilities(img *commands.Image) tasks.TaskFunc {
+	scan := gui.State.VulnerabilityScanRequests[img.ID]
+	delete(gui.State.VulnerabilityScanRequests, img.ID)
+
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			report := gui.DockerCommand.VulnerabilityReport(img.ID)
+
+			if report == nil {
+				if !scan {
+					gui.RenderStringMain(gui.Tr.ImageNotScanned)
+					return
+				}
+
+				gui.RenderStringMain(gui.Tr.ScanningImage)
+
+				var err error
+				report, err = gui.DockerCommand.ScanImage(ctx, img)
+				if err != nil {
+					if ctx.Err() == nil {
+						gui.RenderStringMain(err.Error())
+					}
+					return
+				}
+
+				// for the severity badge
+				if err := gui.Panels.Images.RerenderList(); err != nil {
+					gui.Log.Error(err)
+				}
+			}
+
+			gui.setMainList(ctx, gui.vulnerabilitiesList(report))
+		},
+	})
+}
+
+// vulnerabilitiesList returns a mainList with a line for each of the
+// vulnerabilities in a report. Pressing 's' changes the order they're in.
+func (gui *Gui) vulnerabilitiesList(report *commands.VulnerabilityReport) *mainList {
+	// we sort a copy because the report is shared with the cache
+	vulns := append([]*commands.Vulnerability{}, report.Vulnerabilities...)
+	commands.SortVulnerabilities(vulns, gui.State.VulnerabilitySort)
+
+	list := &mainList{}
+
+	list.render = func() (string, []string) {
+		summary := utils.ResolvePlaceholderString(gui.Tr.VulnerabilitiesSummary, map[string]string{
+			"time":   utils.ResolvePlaceholderString(gui.Tr.TimeAgo, map[string]string{"duration": units.HumanDuration(time.Since(report.ScannedAt))}),
+			"counts": gui.vulnerabilityCounts(report),
+		})
+		if len(vulns) == 0 {
+			return summary, nil
+		}
+
+		sortColumns := map[commands.VulnerabilitySort]string{
+			commands.SortBySeverity:     gui.Tr.SeverityColumn,
+			commands.SortByPackage:      gui.Tr.PackageColumn,
+			commands.SortByFixedVersion: gui.Tr.FixedVersionColumn,
+		}
+		sortedBy := utils.ResolvePlaceholderString(gui.Tr.VulnerabilitiesSortedBy, map[string]string{
+			"column": strings.ToLower(sortColumns[gui.State.VulnerabilitySort]),
+		})
+
+		rows := [][]string{{
+			gui.Tr.SeverityColumn,
+			gui.Tr.IDColumn,
+			gui.Tr.PackageColumn,
+			gui.Tr.InstalledVersionColumn,
+			gui.Tr.FixedVersionColumn,
+			gui.Tr.TitleColumn,
+		}}
+		for _, vuln := range vulns {
+			rows = append(rows, []string{
+				utils.ColoredString(vuln.Severity, presentation.SeverityColor(vuln.Severity)),
+				vuln.ID,
+				vuln.Package,
+				vuln.InstalledVersion,
+				utils.ColoredString(vuln.FixedVersion, color.FgGreen),
+				utils.SafeTruncate(vuln.Title, 80),
+			})
+		}
+
+		table, err := utils.RenderTable(rows)
+		if err != nil {
+			gui.Log.Error(err)
+			return summary, nil
+		}
+		lines := strings.Split(table, "\n")
+
+		return summary + "\n" + utils.ColoredString(sortedBy, color.FgBlue) + "\n\n" + lines[0], lines[1:]
+	}
+
+	list.keyHandlers = map[rune]func(int) error{
+		's': func(int) error {
+			gui.State.VulnerabilitySort = (gui.State.VulnerabilitySort + 1) % commands.VulnerabilitySortCount
+			commands.SortVulnerabilities(vulns, gui.State.VulnerabilitySort)
+			gui.renderMainList()
+			return nil
+		},
+	}
+
+	return list
+}
+
+// vulnerabilityCounts lists how many vulnerabilities a report has of each
+// severity, e.g. '2 critical, 5 high'
+func (gui *Gui) vulnerabilityCounts(report *commands.VulnerabilityReport) string {
+	if len(report.Vulnerabilities) == 0 {
+		return utils.ColoredString(gui.Tr.NoVulnerabilities, color.FgGreen)
+	}
+
+	counts := report.Counts()
+	parts := lo.FilterMap(commands.Severities, func(severity string, _ int) (string, bool) {
+		if counts[severity] == 0 {
+			return "", false
+		}
+		return utils.ColoredString(fmt.Sprintf("%d %s", counts[severity], strings.ToLower(severity)), presentation.SeverityColor(severity)), true
+	})
+
+	return strings.Join(parts, ", ")
+}
+
+// handleImagesScanVulnerabilities scans the selected image for
+// vulnerabilities, or scans it again e.g. because the scanner's database has
+// been updated since we last scanned it, and shows the results
+func (gui *Gui) handleImagesScanVulnerabilities(g *gocui.Gui, v *gocui.View) error {
+	img, err := gui.Panels.Images.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	if err := gui.DockerCommand.ForgetVulnerabilityReport(img.ID); err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+	gui.State.VulnerabilityScanRequests[img.ID] = true
+
+	_, tabIdx, _ := lo.FindIndexOf(gui.Panels.Images.ContextState.GetMainTabs(), func(tab panels.MainTab[*commands.Image]) bool {
+		return tab.Key == "vulnerabilities"
+	})
+	gui.Panels.Images.SetMainTabIndex(tabIdx)
+
+	// the tab's cache key hasn't changed, so we have to force it to re-render.
+	// Rerendering the list (to drop the image's badge) re-renders the tab.
+	gui.State.Panels.Main.ObjectKey = ""
+	return gui.Panels.Images.RerenderList()
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -30,10 +31,37 @@ func (gui *Gui) getImagesPanel() *panels.SideListPanel[*commands.Image] {
 						Title:  gui.Tr.ConfigTitle,
 						Render: gui.renderImageConfigTask,
 					},
+					{
+						Key:    "tags",
+						Title:  gui.Tr.TagsTitle,
+						Render: gui.renderImageTags,
+					},
+					{
+						Key:    "layers",
+						Title:  gui.Tr.LayersTitle,
+						Render: gui.renderImageLayers,
+					},
+					{
+						Key:    "containers",
+						Title:  gui.Tr.ContainersTitle,
+						Render: gui.renderImageContainers,
+					},
+					{
+						Key:    "vulnerabilities",
+						Title:  gui.Tr.VulnerabilitiesTitle,
+						Render: gui.renderImageVulnerabilities,
+					},
 				}
 			},
 			GetItemContextCacheKey: func(image *commands.Image) string {
-				return "images-" + image.ID
+				// Including the image's references in the cache key so that the tags
+				// tab is re-rendered when we add or remove a tag, or push one. Likewise
+				// the containers using the image, for the containers tab.
+				refs := append(image.RepoTags(), image.Image.RepoDigests...)
+				usage := lo.Map(gui.imageContainers(image), func(ctr *commands.Container, _ int) string {
+					return ctr.ID + ":" + ctr.Container.State
+				})
+				return "images-" + image.ID + "-" + strings.Join(refs, ",") + "-" + strings.Join(usage, ",")
 			},
 		},
 		ListPanel: panels.ListPanel[*commands.Image]{
@@ -61,10 +89,25 @@ func (gui *Gui) getImagesPanel() *panels.SideListPanel[*commands.Image] {
 
 			return a.ID < b.ID
 		},
-		GetTableCells: presentation.GetImageDisplayStrings,
+		Filter: func(image *commands.Image) bool {
+			switch gui.State.ImagesView {
+			case imagesViewDangling:
+				return image.IsDangling()
+			case imagesViewUnused:
+				return len(gui.imageContainers(image)) == 0
+			default:
+				return true
+			}
+		},
+		GetTableCells: func(image *commands.Image) []string {
+			return presentation.GetImageDisplayStrings(gui.Tr, image, gui.imageContainers(image), gui.DockerCommand.VulnerabilityReport(image.ID))
+		},
 		Hide: func() bool {
 			return gui.State.UIMode != MODE_RESSOURCES
 		},
+		GetItemKey: func(image *commands.Image) string {
+			return image.ID
+		},
 	}
 }
 
@@ -95,6 +138,30 @@ func (gui *Gui) imageConfigStr(image *commands.Image) string {
 	return output
 }
 
+// imageContainers returns the containers created from the given image
+func (gui *Gui) imageContainers(image *commands.Image) []*commands.Container {
+	return image.Containers(gui.Panels.Containers.List.GetAllItems())
+}
+
+func (gui *Gui) renderImageContainers(image *commands.Image) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			containers := gui.imageContainers(image)
+			if len(containers) == 0 {
+				gui.RenderStringMain(gui.Tr.ImageNotInUse)
+				return
+			}
+
+			header := utils.ResolvePlaceholderString(gui.Tr.ImageUsedBy, map[string]string{
+				"count": fmt.Sprint(len(containers)),
+			})
+			gui.setMainList(ctx, gui.containerUsageList(header, containers, nil))
+		},
+	})
+}
+
 func (gui *Gui) reloadImages() error {
 	if err := gui.refreshStateImages(); err != nil {
 		return err
@@ -114,6 +181,23 @@ func (gui *Gui) refreshStateImages() error {
 	return nil
 }
 
+// selectImage refreshes the images panel and then focuses the image with the
+// given ID, e.g. one we've just created
+func (gui *Gui) selectImage(imageID string) error {
+	return selectListItem(gui, gui.Panels.Images, gui.refreshStateImages, func(image *commands.Image) bool {
+		return image.ID == imageID
+	})
+}
+
+// selectImageByRef is like selectImage but takes either an image ID or a
+// repository:tag (or repository@digest) reference, e.g. one we've just
+// pulled or loaded
+func (gui *Gui) selectImageByRef(ref string) error {
+	return selectListItem(gui, gui.Panels.Images, gui.refreshStateImages, func(image *commands.Image) bool {
+		return image.ID == ref || lo.Contains(image.Image.RepoTags, ref) || lo.Contains(image.Image.RepoDigests, ref)
+	})
+}
+
 func (gui *Gui) FilterString(view *gocui.View) string {
 	if gui.State.Filter.panel != nil && gui.State.Filter.panel.GetView() != view {
 		return ""
@@ -123,10 +207,8 @@ func (gui *Gui) FilterString(view *gocui.View) string {
 }
 
 func (gui *Gui) handleImagesRemoveMenu(g *gocui.Gui, v *gocui.View) error {
-	type removeImageOption struct {
-		description   string
-		command       string
-		configOptions image.RemoveOptions
+	if marked := gui.Panels.Images.MarkedItems(); len(marked) > 0 {
+		return gui.batchImageRemoveMenu(marked)
 	}
 
 	img, err := gui.Panels.Images.GetSelectedItem()
@@ -134,6 +216,30 @@ func (gui *Gui) handleImagesRemoveMenu(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
+	// docker refuses to remove an image that a container uses unless forced,
+	// and forcing it leaves the container pointing at an untagged image, so
+	// we give a heads up first
+	if containers := gui.imageContainers(img); len(containers) > 0 {
+		names := lo.Map(containers, func(ctr *commands.Container, _ int) string { return ctr.Name })
+		message := utils.ResolvePlaceholderString(gui.Tr.ImageInUseWarning, map[string]string{
+			"count":      fmt.Sprint(len(containers)),
+			"containers": strings.Join(names, ", "),
+		})
+		return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+			return gui.showImageRemoveMenu(img)
+		}, nil)
+	}
+
+	return gui.showImageRemoveMenu(img)
+}
+
+func (gui *Gui) showImageRemoveMenu(img *commands.Image) error {
+	type removeImageOption struct {
+		description   string
+		command       string
+		configOptions image.RemoveOptions
+	}
+
 	shortSha := img.ID[7:17]
 
 	// TODO: have a way of toggling in a menu instead of showing each permutation as a separate menu item
@@ -182,19 +288,11 @@ func (gui *Gui) handleImagesRemoveMenu(g *gocui.Gui, v *gocui.View) error {
 	})
 }
 
-func (gui *Gui) handlePruneImages() error {
-	return gui.createConfirmationPanel(gui.Tr.Confirm, gui.Tr.ConfirmPruneImages, func(g *gocui.Gui, v *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
-			err := gui.DockerCommand.PruneImages()
-			if err != nil {
-				return gui.createErrorPanel(err.Error())
-			}
-			return gui.reloadImages()
-		})
-	}, nil)
-}
-
 func (gui *Gui) handleImagesCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Images.MarkedItems(); len(marked) > 0 {
+		return gui.batchImagesCustomCommand(marked)
+	}
+
 	img, err := gui.Panels.Images.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -198,6 +198,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleToggleProjectMode,
 			Description: gui.Tr.ToggleProjectMode,
 		},
+		{
+			ViewName:    "",
+			Key:         'F',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleDiskUsage,
+			Description: gui.Tr.ViewDiskUsage,
+		},
+		{
+			ViewName:    "",
+			Key:         'W',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCleanup,
+			Description: gui.Tr.CleanUp,
+		},
 		{
 			ViewName:    "",
 			Key:         '0',
@@ -358,6 +372,48 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleContainersOpenInBrowserCommand,
 			Description: gui.Tr.OpenInBrowser,
 		},
+		{
+			ViewName:    "containers",
+			Key:         'C',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerCopyMenu,
+			Description: gui.Tr.CopyFiles,
+		},
+		{
+			ViewName:    "containers",
+			Key:         'i',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerCommit,
+			Description: gui.Tr.CommitContainer,
+		},
+		{
+			ViewName:    "containers",
+			Key:         'T',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerExport,
+			Description: gui.Tr.ExportContainer,
+		},
+		{
+			ViewName:    "containers",
+			Key:         'U',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerPullAndRecreate,
+			Description: gui.Tr.PullAndRecreate,
+		},
+		{
+			ViewName:    "containers",
+			Key:         'n',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerConnectNetwork,
+			Description: gui.Tr.ConnectToNetwork,
+		},
+		{
+			ViewName:    "containers",
+			Key:         'N',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerDisconnectNetwork,
+			Description: gui.Tr.DisconnectFromNetwork,
+		},
 		{
 			ViewName:    "services",
 			Key:         'u',
@@ -435,6 +491,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleServiceRestartMenu,
 			Description: gui.Tr.ViewRestartOptions,
 		},
+		{
+			ViewName:    "services",
+			Key:         'U',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleServicePullAndRecreate,
+			Description: gui.Tr.PullAndRecreate,
+		},
+		{
+			ViewName:    "services",
+			Key:         'n',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerConnectNetwork,
+			Description: gui.Tr.ConnectToNetwork,
+		},
+		{
+			ViewName:    "services",
+			Key:         'N',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleContainerDisconnectNetwork,
+			Description: gui.Tr.DisconnectFromNetwork,
+		},
 		{
 			ViewName:    "services",
 			Key:         'c',
@@ -463,6 +540,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleServicesOpenInBrowserCommand,
 			Description: gui.Tr.OpenInBrowser,
 		},
+		{
+			ViewName:    "services",
+			Key:         'C',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleServiceCopyMenu,
+			Description: gui.Tr.CopyFiles,
+		},
 		{
 			ViewName:    "images",
 			Key:         'c',
@@ -477,6 +561,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleImagesRemoveMenu,
 			Description: gui.Tr.RemoveImage,
 		},
+		{
+			ViewName:    "images",
+			Key:         'e',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesToggleView,
+			Description: gui.Tr.ToggleImagesView,
+		},
+		{
+			ViewName:    "images",
+			Key:         'C',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleBuildCache,
+			Description: gui.Tr.ViewBuildCache,
+		},
+		{
+			ViewName:    "images",
+			Key:         'S',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesScanVulnerabilities,
+			Description: gui.Tr.ScanImage,
+		},
 		{
 			ViewName:    "images",
 			Key:         'b',
@@ -484,6 +589,41 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleImagesBulkCommand,
 			Description: gui.Tr.ViewBulkCommands,
 		},
+		{
+			ViewName:    "images",
+			Key:         'T',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesArchiveMenu,
+			Description: gui.Tr.SaveLoadImages,
+		},
+		{
+			ViewName:    "images",
+			Key:         'p',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesPullMenu,
+			Description: gui.Tr.PullImage,
+		},
+		{
+			ViewName:    "images",
+			Key:         'B',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesBuild,
+			Description: gui.Tr.BuildImage,
+		},
+		{
+			ViewName:    "images",
+			Key:         't',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesTagsMenu,
+			Description: gui.Tr.ManageTags,
+		},
+		{
+			ViewName:    "images",
+			Key:         'u',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleImagesPushMenu,
+			Description: gui.Tr.PushImage,
+		},
 		{
 			ViewName:    "volumes",
 			Key:         'c',
@@ -505,6 +645,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleVolumesBulkCommand,
 			Description: gui.Tr.ViewBulkCommands,
 		},
+		{
+			ViewName:    "volumes",
+			Key:         'n',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleVolumesCreate,
+			Description: gui.Tr.CreateVolume,
+		},
+		{
+			ViewName:    "volumes",
+			Key:         'B',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleVolumesBackupMenu,
+			Description: gui.Tr.BackupAndRestore,
+		},
 		{
 			ViewName:    "networks",
 			Key:         'c',
@@ -526,6 +680,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleNetworksBulkCommand,
 			Description: gui.Tr.ViewBulkCommands,
 		},
+		{
+			ViewName:    "networks",
+			Key:         'n',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNetworksCreate,
+			Description: gui.Tr.CreateNetwork,
+		},
+		{
+			ViewName:    "networks",
+			Key:         'a',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNetworksConnectContainer,
+			Description: gui.Tr.ConnectContainer,
+		},
+		{
+			ViewName:    "networks",
+			Key:         'D',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNetworksDisconnectContainer,
+			Description: gui.Tr.DisconnectContainer,
+		},
 		{
 			ViewName:    "main",
 			Key:         gocui.KeyEsc,
@@ -533,6 +708,34 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleExitMain,
 			Description: gui.Tr.Return,
 		},
+		{
+			ViewName:    "main",
+			Key:         gocui.KeyEnter,
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleMainListPress,
+			Description: gui.Tr.PressMainListItem,
+		},
+		{
+			ViewName:    "main",
+			Key:         'C',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleMainListKey('C'),
+			Description: gui.Tr.CopyFromContainer,
+		},
+		{
+			ViewName:    "main",
+			Key:         'p',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleMainBuildCachePrune,
+			Description: gui.Tr.PruneBuildCache,
+		},
+		{
+			ViewName:    "main",
+			Key:         's',
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleMainListKey('s'),
+			Description: gui.Tr.SortVulnerabilities,
+		},
 		{
 			ViewName: "main",
 			Key:      gocui.KeyArrowLeft,
@@ -647,7 +850,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		setUpDownClickBindings(panel.GetView().Name(), panel.HandlePrevLine, panel.HandleNextLine, panel.HandleClick)
 	}
 
-	setUpDownClickBindings("main", gui.scrollUpMain, gui.scrollDownMain, gui.handleMainClick)
+	setUpDownClickBindings("main", gui.handleMainPrevLine, gui.handleMainNextLine, gui.handleMainClick)
 
 	for _, panel := range gui.allSidePanels() {
 		bindings = append(bindings,
@@ -675,6 +878,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		)
 	}
 
+	for _, panel := range gui.allSidePanels() {
+		if panel.CanMark() {
+			bindings = append(bindings,
+				&Binding{
+					ViewName:    panel.GetView().Name(),
+					Key:         gocui.KeySpace,
+					Modifier:    gocui.ModNone,
+					Handler:     wrappedHandler(panel.HandleToggleMark),
+					Description: gui.Tr.MarkItem,
+				},
+				&Binding{
+					ViewName:    panel.GetView().Name(),
+					Key:         'v',
+					Modifier:    gocui.ModNone,
+					Handler:     wrappedHandler(panel.HandleToggleRange),
+					Description: gui.Tr.MarkRange,
+				},
+			)
+		}
+	}
+
 	for _, panel := range gui.allListPanels() {
 		if !panel.IsFilterDisabled() {
 			bindings = append(bindings, &Binding{
@@ -0,0 +1,163 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// the width of a layer's progress bar, not counting its brackets
+const progressBarWidth = 30
+
+// the statuses docker gives a layer once it's done with it
+var layerDoneStatuses = []string{"Pull complete", "Already exists", "Pushed", "Layer already exists", "Mounted from"}
+
+// layerProgress tracks a docker pull or push from its stream of progress
+// messages, keeping the latest message for each layer so that we can render a
+// progress bar per layer. Messages that aren't about a layer (e.g. the digest
+// of the pulled image) are kept as a log below the layers.
+type layerProgress struct {
+	mutex    deadlock.Mutex
+	title    string
+	layerIDs []string
+	layers   map[string]jsonmessage.JSONMessage
+	log      []string
+	finished bool
+}
+
+func newLayerProgress(title string) *layerProgress {
+	return &layerProgress{
+		title:  title,
+		layers: map[string]jsonmessage.JSONMessage{},
+	}
+}
+
+func (p *layerProgress) update(message jsonmessage.JSONMessage) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if message.ID == "" || !p.isLayerStatus(message) {
+		line := strings.TrimSpace(message.Status + message.Stream)
+		if line != "" && message.ID != "" {
+			line = message.ID + ": " + line
+		}
+		if line != "" {
+			p.log = append(p.log, line)
+		}
+		return
+	}
+
+	if _, ok := p.layers[message.ID]; !ok {
+		p.layerIDs = append(p.layerIDs, message.ID)
+	}
+	p.layers[message.ID] = message
+}
+
+// isLayerStatus tells us whether a message with an ID is about a layer, as
+// opposed to e.g. 'latest: Pulling from library/alpine', which docker sends
+// with the tag as its ID
+func (p *layerProgress) isLayerStatus(message jsonmessage.JSONMessage) bool {
+	if _, ok := p.layers[message.ID]; ok {
+		return true
+	}
+
+	return !strings.HasPrefix(message.Status, "Pulling from")
+}
+
+// finish marks the pull or push as finished, after which we stop re-rendering
+// it, and adds a final line to the log if it's not empty
+func (p *layerProgress) finish(line string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.finished = true
+	if line != "" {
+		p.log = append(p.log, line)
+	}
+}
+
+func (p *layerProgress) isFinished() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.finished
+}
+
+// summary returns e.g. '3/7 layers' for the status bar
+func (p *layerProgress) summary() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	done := 0
+	for _, id := range p.layerIDs {
+		if isLayerDone(p.layers[id]) {
+			done++
+		}
+	}
+
+	return fmt.Sprintf("%d/%d layers", done, len(p.layerIDs))
+}
+
+func (p *layerProgress) render() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	lines := []string{utils.ColoredString(p.title, color.FgCyan), ""}
+	for _, id := range p.layerIDs {
+		lines = append(lines, renderLayerLine(id, p.layers[id]))
+	}
+	if len(p.log) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, p.log...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderLayerLine(id string, message jsonmessage.JSONMessage) string {
+	status := message.Status
+	if isLayerDone(message) {
+		status = utils.ColoredString(status, color.FgGreen)
+	}
+
+	line := utils.ColoredString(id, color.FgYellow) + ": " + status
+
+	progress := message.Progress
+	if progress == nil || progress.Total <= 0 || isLayerDone(message) {
+		return line
+	}
+
+	return fmt.Sprintf("%s %s %s/%s",
+		line,
+		renderProgressBar(progress.Current, progress.Total),
+		utils.FormatBinaryBytes(int(progress.Current)),
+		utils.FormatBinaryBytes(int(progress.Total)),
+	)
+}
+
+// renderProgressBar returns e.g. '[=========>      ]'
+func renderProgressBar(current, total int64) string {
+	filled := int(float64(progressBarWidth) * float64(current) / float64(total))
+	filled = max(0, min(filled, progressBarWidth))
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	return "[" + bar + "]"
+}
+
+func isLayerDone(message jsonmessage.JSONMessage) bool {
+	for _, status := range layerDoneStatuses {
+		if strings.HasPrefix(message.Status, status) {
+			return true
+		}
+	}
+
+	return false
+}
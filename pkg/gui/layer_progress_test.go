@@ -0,0 +1,50 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerProgress(t *testing.T) {
+	progress := newLayerProgress("Pulling alpine:latest")
+
+	messages := []jsonmessage.JSONMessage{
+		{ID: "latest", Status: "Pulling from library/alpine"},
+		{ID: "aaa", Status: "Pulling fs layer"},
+		{ID: "bbb", Status: "Already exists"},
+		{ID: "aaa", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 512, Total: 1024}},
+		{Status: "Digest: sha256:1234"},
+	}
+	for _, message := range messages {
+		progress.update(message)
+	}
+
+	assert.EqualValues(t, "1/2 layers", progress.summary())
+
+	lines := strings.Split(utils.Decolorise(progress.render()), "\n")
+	assert.EqualValues(t, []string{
+		"Pulling alpine:latest",
+		"",
+		"aaa: Downloading [===============>              ] 512.00B/1024.00B",
+		"bbb: Already exists",
+		"",
+		"latest: Pulling from library/alpine",
+		"Digest: sha256:1234",
+	}, lines)
+
+	assert.False(t, progress.isFinished())
+	progress.update(jsonmessage.JSONMessage{ID: "aaa", Status: "Pull complete"})
+	progress.finish("")
+	assert.True(t, progress.isFinished())
+	assert.EqualValues(t, "2/2 layers", progress.summary())
+}
+
+func TestRenderProgressBar(t *testing.T) {
+	assert.EqualValues(t, "[>"+strings.Repeat(" ", progressBarWidth-1)+"]", renderProgressBar(0, 100))
+	assert.EqualValues(t, "["+strings.Repeat("=", progressBarWidth)+"]", renderProgressBar(100, 100))
+	assert.EqualValues(t, "["+strings.Repeat("=", progressBarWidth)+"]", renderProgressBar(200, 100))
+}
@@ -27,7 +27,7 @@ func (gui *Gui) getFocusLayout() func(g *gocui.Gui) error {
 func (gui *Gui) onFocusChange() error {
 	currentView := gui.g.CurrentView()
 	for _, view := range gui.g.Views() {
-		view.Highlight = view == currentView && view.Name() != "main"
+		view.Highlight = view == currentView && (view.Name() != "main" || gui.State.Panels.Main.List != nil)
 	}
 	return nil
 }
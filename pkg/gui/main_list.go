@@ -0,0 +1,215 @@
+package gui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// A mainList is an interactive list that a main tab renders to the main view,
+// for example the files inside a container. While the main view is focused,
+// moving up and down selects a line of the list rather than scrolling, and
+// pressing enter (or one of the list's own keys) acts on the selected line.
+type mainList struct {
+	// returns a header to show above the list (can be empty) along with the
+	// list's lines. It's called every time the list is rendered.
+	render func() (string, []string)
+
+	selectedIdx int
+
+	// called with the selected line's index when enter is pressed. Can be nil
+	onPress func(idx int) error
+
+	// handlers for other keys, called with the selected line's index. Each key
+	// also needs a binding on the main view which calls handleMainListKey
+	keyHandlers map[rune]func(idx int) error
+
+	// the number of lines in the list and in its header as of the last render
+	lineCount    int
+	headerHeight int
+}
+
+// setMainList makes the given list the main view's content. It's meant to be
+// called from a task, so if the task has since been cancelled we do nothing.
+func (gui *Gui) setMainList(ctx context.Context, list *mainList) {
+	gui.Update(func() error {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		gui.State.Panels.Main.List = list
+		gui.Views.Main.SetOrigin(0, 0)
+		gui.Views.Main.Wrap = false
+		gui.Views.Main.Autoscroll = false
+		gui.renderMainList()
+		return nil
+	})
+}
+
+// loadMainList renders a list to the main view which takes a while to load,
+// e.g. because docker is slow to give us what's in it. We load the list afresh
+// even if the main view is already showing it.
+func (gui *Gui) loadMainList(key string, loadingMessage string, load func(ctx context.Context) (*mainList, error)) error {
+	gui.State.Panels.Main.ObjectKey = ""
+	gui.ShouldRefresh(key)
+
+	return gui.QueueTask(gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			gui.RenderStringMain(loadingMessage)
+
+			list, err := load(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					gui.RenderStringMain(err.Error())
+				}
+				return
+			}
+
+			gui.setMainList(ctx, list)
+		},
+	}))
+}
+
+// focusMain focuses the main view so that the user can interact with its list
+// without first selecting something in a side panel. Escape takes them back to
+// the view they were in.
+func (gui *Gui) focusMain() error {
+	if currentView := gui.g.CurrentView(); currentView != nil && currentView.Name() != "main" {
+		gui.Views.Main.ParentView = currentView
+	}
+
+	return gui.switchFocus(gui.Views.Main)
+}
+
+// clearMainList drops the main view's list, if it has one. This must be done
+// whenever the main view moves on to a different context.
+func (gui *Gui) clearMainList() {
+	gui.State.Panels.Main.List = nil
+	gui.Views.Main.Highlight = false
+}
+
+// rerenderMainList re-renders the given list if it's still the main view's
+// list. It's safe to call from outside the UI thread.
+func (gui *Gui) rerenderMainList(list *mainList) {
+	gui.Update(func() error {
+		if gui.State.Panels.Main.List != list {
+			return nil
+		}
+
+		gui.renderMainList()
+		return nil
+	})
+}
+
+// renderMainList must be called from the UI thread
+func (gui *Gui) renderMainList() {
+	list := gui.State.Panels.Main.List
+	if list == nil {
+		return
+	}
+
+	header, lines := list.render()
+	list.lineCount = len(lines)
+	if list.selectedIdx > len(lines)-1 {
+		list.selectedIdx = len(lines) - 1
+	}
+	if list.selectedIdx < 0 {
+		list.selectedIdx = 0
+	}
+
+	content := strings.Join(lines, "\n")
+	list.headerHeight = 0
+	if header != "" {
+		content = header + "\n" + content
+		list.headerHeight = len(strings.Split(header, "\n"))
+	}
+
+	mainView := gui.Views.Main
+	_ = gui.setViewContent(mainView, content)
+
+	mainView.Highlight = gui.currentViewName() == "main" && len(lines) > 0
+	gui.FocusY(list.headerHeight+list.selectedIdx, list.headerHeight+len(lines), mainView)
+}
+
+// focusedMainList returns the main view's list if the user is interacting
+// with it i.e. if the main view is focused
+func (gui *Gui) focusedMainList() *mainList {
+	if gui.currentViewName() != "main" {
+		return nil
+	}
+
+	return gui.State.Panels.Main.List
+}
+
+func (gui *Gui) handleMainPrevLine() error {
+	list := gui.focusedMainList()
+	if list == nil {
+		return gui.scrollUpMain()
+	}
+
+	if list.selectedIdx > 0 {
+		list.selectedIdx--
+	}
+	gui.renderMainList()
+	return nil
+}
+
+func (gui *Gui) handleMainNextLine() error {
+	list := gui.focusedMainList()
+	if list == nil {
+		return gui.scrollDownMain()
+	}
+
+	if list.selectedIdx < list.lineCount-1 {
+		list.selectedIdx++
+	}
+	gui.renderMainList()
+	return nil
+}
+
+func (gui *Gui) handleMainListPress(g *gocui.Gui, v *gocui.View) error {
+	list := gui.focusedMainList()
+	if list == nil || list.onPress == nil || list.lineCount == 0 {
+		return nil
+	}
+
+	return list.onPress(list.selectedIdx)
+}
+
+func (gui *Gui) handleMainListKey(key rune) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		list := gui.focusedMainList()
+		if list == nil || list.lineCount == 0 {
+			return nil
+		}
+
+		handler, ok := list.keyHandlers[key]
+		if !ok {
+			return nil
+		}
+
+		return handler(list.selectedIdx)
+	}
+}
+
+// selectMainListLineAtCursor selects whichever line of the main view's list
+// the user clicked on
+func (gui *Gui) selectMainListLineAtCursor() {
+	list := gui.State.Panels.Main.List
+	if list == nil {
+		return
+	}
+
+	_, cy := gui.Views.Main.Cursor()
+	_, oy := gui.Views.Main.Origin()
+	idx := cy + oy - list.headerHeight
+	if idx < 0 || idx >= list.lineCount {
+		return
+	}
+
+	list.selectedIdx = idx
+	gui.renderMainList()
+}
@@ -98,7 +98,12 @@ func (gui *Gui) handleEnterMain(g *gocui.Gui, v *gocui.View) error {
 	mainView := gui.Views.Main
 	mainView.ParentView = v
 
-	return gui.switchFocus(mainView)
+	if err := gui.switchFocus(mainView); err != nil {
+		return err
+	}
+
+	gui.renderMainList()
+	return nil
 }
 
 func (gui *Gui) handleExitMain(g *gocui.Gui, v *gocui.View) error {
@@ -117,5 +122,10 @@ func (gui *Gui) handleMainClick() error {
 		gui.Views.Main.ParentView = currentView
 	}
 
-	return gui.switchFocus(gui.Views.Main)
+	if err := gui.switchFocus(gui.Views.Main); err != nil {
+		return err
+	}
+
+	gui.selectMainListLineAtCursor()
+	return nil
 }
@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"context"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// mainProgress is a long-running operation whose progress we render to the
+// main view, e.g. an image pull or build
+type mainProgress interface {
+	render() string
+	isFinished() bool
+}
+
+// showProgressInMain renders an operation's progress to the main view until it
+// finishes or the user moves on to something else. The operation keeps going
+// in the latter case. If autoscroll is true we follow the end of the output
+// while it's in progress. onFinished, if not nil, is called with the main view
+// once we've rendered the final output, e.g. to scroll to an error.
+func (gui *Gui) showProgressInMain(key string, progress mainProgress, autoscroll bool, onFinished func(v *gocui.View)) error {
+	gui.ShouldRefresh(key)
+
+	return gui.QueueTask(gui.NewTickerTask(TickerTaskOpts{
+		Func: func(ctx context.Context, notifyStopped chan struct{}) {
+			// checking this before rendering so that we don't miss any final output
+			finished := progress.isFinished()
+			content := progress.render()
+
+			gui.g.Update(func(*gocui.Gui) error {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err := gui.setViewContent(gui.Views.Main, content); err != nil {
+					return err
+				}
+				if finished && onFinished != nil {
+					onFinished(gui.Views.Main)
+				}
+				return nil
+			})
+
+			if finished {
+				notifyStopped <- struct{}{}
+			}
+		},
+		Duration:   time.Millisecond * 100,
+		Before:     func(ctx context.Context) { gui.clearMainView() },
+		Wrap:       false,
+		Autoscroll: autoscroll,
+	}))
+}
@@ -102,6 +102,48 @@ func (gui *Gui) Menu(opts CreateMenuOptions) error {
 	return gui.switchFocus(gui.Views.Menu)
 }
 
+// checkboxMenuItem is an option in a checkbox menu
+type checkboxMenuItem struct {
+	// the checkbox goes before the first column
+	LabelColumns []string
+	Checked      bool
+	OnToggle     func()
+}
+
+// checkboxMenu shows a menu with the given action first, followed by the
+// options, each with a checkbox. Toggling an option calls reopen with its
+// index in the menu, so that the caller can show the menu again with the
+// option's new value, and anything that depends on it worked out again.
+// selectedIdx is the index of the item to select.
+func (gui *Gui) checkboxMenu(title string, action *types.MenuItem, options []checkboxMenuItem, selectedIdx int, reopen func(selectedIdx int) error) error {
+	checkbox := func(checked bool) string {
+		if checked {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+
+	items := []*types.MenuItem{action}
+	for i, option := range options {
+		i, option := i, option
+		labelColumns := append([]string{checkbox(option.Checked) + " " + option.LabelColumns[0]}, option.LabelColumns[1:]...)
+		items = append(items, &types.MenuItem{
+			LabelColumns: labelColumns,
+			OnPress: func() error {
+				option.OnToggle()
+				return reopen(i + 1)
+			},
+		})
+	}
+
+	if err := gui.Menu(CreateMenuOptions{Title: title, Items: items}); err != nil {
+		return err
+	}
+
+	gui.Panels.Menu.SetSelectedLineIdx(selectedIdx)
+	return nil
+}
+
 // specific functions
 
 func (gui *Gui) renderMenuOptions() error {
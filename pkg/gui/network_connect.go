@@ -0,0 +1,254 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/presentation"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// networkEndpointsStr renders a table of containers' connections to networks,
+// indented by the given padding. nameOf returns the name to show for each
+// connection: the container's or the network's.
+func (gui *Gui) networkEndpointsStr(padding int, nameColumn string, endpoints []*commands.NetworkEndpoint, nameOf func(*commands.NetworkEndpoint) string) string {
+	if len(endpoints) == 0 {
+		return "none\n"
+	}
+
+	rows := [][]string{{nameColumn, gui.Tr.IPv4Column, gui.Tr.IPv6Column, gui.Tr.MACColumn, gui.Tr.AliasesColumn}}
+	for _, endpoint := range endpoints {
+		rows = append(rows, presentation.GetNetworkEndpointDisplayStrings(nameOf(endpoint), endpoint))
+	}
+
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+		return "none\n"
+	}
+
+	output := "\n"
+	for _, line := range strings.Split(table, "\n") {
+		output += strings.Repeat(" ", padding) + line + "\n"
+	}
+	return output
+}
+
+// selectedContainer returns the container selected in the containers panel or,
+// if the services panel is focused, the selected service's container
+func (gui *Gui) selectedContainer(v *gocui.View) (*commands.Container, bool) {
+	if v.Name() == gui.Panels.Services.View.Name() {
+		service, err := gui.Panels.Services.GetSelectedItem()
+		if err != nil || service.Container == nil {
+			return nil, false
+		}
+		return service.Container, true
+	}
+
+	ctr, err := gui.Panels.Containers.GetSelectedItem()
+	if err != nil {
+		return nil, false
+	}
+	return ctr, true
+}
+
+func (gui *Gui) handleContainerConnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	ctr, ok := gui.selectedContainer(v)
+	if !ok {
+		return nil
+	}
+
+	networks := lo.Filter(gui.Panels.Networks.List.GetAllItems(), func(network *commands.Network, _ int) bool {
+		return !network.IsConnected(ctr)
+	})
+	if len(networks) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoNetworksToConnect)
+	}
+
+	menuItems := lo.Map(networks, func(network *commands.Network, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			LabelColumns: presentation.GetNetworkDisplayStrings(network),
+			OnPress: func() error {
+				return gui.promptNetworkConnect(network, ctr)
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.ConnectContainerToNetworkTitle, map[string]string{"name": ctr.Name}),
+		Items: menuItems,
+	})
+}
+
+func (gui *Gui) handleContainerDisconnectNetwork(g *gocui.Gui, v *gocui.View) error {
+	ctr, ok := gui.selectedContainer(v)
+	if !ok {
+		return nil
+	}
+
+	networks := lo.Filter(gui.Panels.Networks.List.GetAllItems(), func(network *commands.Network, _ int) bool {
+		return network.IsConnected(ctr)
+	})
+	if len(networks) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoNetworksToDisconnect)
+	}
+
+	menuItems := lo.Map(networks, func(network *commands.Network, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			LabelColumns: presentation.GetNetworkDisplayStrings(network),
+			OnPress: func() error {
+				return gui.confirmNetworkDisconnect(network, ctr)
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.DisconnectContainerFromNetworkTitle, map[string]string{"name": ctr.Name}),
+		Items: menuItems,
+	})
+}
+
+func (gui *Gui) handleNetworksConnectContainer(g *gocui.Gui, v *gocui.View) error {
+	network, err := gui.Panels.Networks.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	containers := lo.Filter(gui.Panels.Containers.List.GetAllItems(), func(ctr *commands.Container, _ int) bool {
+		return ctr.DetailsLoaded() && !network.IsConnected(ctr)
+	})
+	if len(containers) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoContainersToConnect)
+	}
+
+	menuItems := lo.Map(containers, func(ctr *commands.Container, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			// the status, substatus and name columns of the containers panel
+			LabelColumns: presentation.GetContainerDisplayStrings(gui.Tr, &gui.Config.UserConfig.Gui, ctr, nil)[:3],
+			OnPress: func() error {
+				return gui.promptNetworkConnect(network, ctr)
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.ConnectContainerTitle, map[string]string{"name": network.Name}),
+		Items: menuItems,
+	})
+}
+
+func (gui *Gui) handleNetworksDisconnectContainer(g *gocui.Gui, v *gocui.View) error {
+	network, err := gui.Panels.Networks.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	endpoints := network.Endpoints(gui.Panels.Containers.List.GetAllItems())
+	if len(endpoints) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoContainersToDisconnect)
+	}
+
+	menuItems := lo.Map(endpoints, func(endpoint *commands.NetworkEndpoint, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			LabelColumns: presentation.GetNetworkEndpointDisplayStrings(endpoint.Container.Name, endpoint),
+			OnPress: func() error {
+				return gui.confirmNetworkDisconnect(network, endpoint.Container)
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.DisconnectContainerTitle, map[string]string{"name": network.Name}),
+		Items: menuItems,
+	})
+}
+
+// promptNetworkConnect asks for the container's aliases and static addresses
+// on the network and then connects it. If an address isn't valid we ask for it
+// again.
+func (gui *Gui) promptNetworkConnect(network *commands.Network, ctr *commands.Container) error {
+	options := commands.NetworkConnectOptions{}
+
+	validate := func(options commands.NetworkConnectOptions) error {
+		_, err := commands.NetworkEndpointSettings(options)
+		return err
+	}
+
+	promptIPv6 := func() error {
+		// docker won't give a container an IPv6 address on a network without IPv6
+		if !network.Network.EnableIPv6 {
+			return gui.connectNetwork(network, ctr, options)
+		}
+
+		validateIPv6 := func(content string) error {
+			result := options
+			result.IPv6Address = content
+			return validate(result)
+		}
+
+		return gui.createValidatedPromptPanel(gui.Tr.IPv6AddressTitle, "", validateIPv6, func(content string) error {
+			options.IPv6Address = content
+
+			return gui.connectNetwork(network, ctr, options)
+		})
+	}
+
+	return gui.createPromptPanel(gui.Tr.AliasesTitle, func(g *gocui.Gui, v *gocui.View) error {
+		options.Aliases = commands.ParseList(gui.trimmedContent(v))
+
+		validateIPv4 := func(content string) error {
+			result := options
+			result.IPv4Address = content
+			return validate(result)
+		}
+
+		return gui.createValidatedPromptPanel(gui.Tr.IPv4AddressTitle, "", validateIPv4, func(content string) error {
+			options.IPv4Address = content
+
+			return promptIPv6()
+		})
+	})
+}
+
+func (gui *Gui) connectNetwork(network *commands.Network, ctr *commands.Container, options commands.NetworkConnectOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.ConnectingStatus, func() error {
+		if err := network.Connect(ctr, options); err != nil {
+			return err
+		}
+
+		return gui.refreshNetworkEndpoints()
+	})
+}
+
+func (gui *Gui) confirmNetworkDisconnect(network *commands.Network, ctr *commands.Container) error {
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmDisconnectNetwork, map[string]string{
+		"container": ctr.Name,
+		"network":   network.Name,
+	})
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.WithWaitingStatus(gui.Tr.DisconnectingStatus, func() error {
+			if err := network.Disconnect(ctr); err != nil {
+				return err
+			}
+
+			return gui.refreshNetworkEndpoints()
+		})
+	}, nil)
+}
+
+// refreshNetworkEndpoints reloads the containers' details after connecting or
+// disconnecting one, so that its config tab and its network's show the change
+func (gui *Gui) refreshNetworkEndpoints() error {
+	// neither tab's cache key has changed, so we have to force a re-render
+	gui.State.Panels.Main.ObjectKey = ""
+
+	if err := gui.refreshContainersAndServices(); err != nil {
+		return err
+	}
+
+	return gui.Panels.Networks.RerenderList()
+}
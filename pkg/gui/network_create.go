@@ -0,0 +1,119 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// handleNetworksCreate prompts for each of the new network's settings in turn,
+// checking the subnets, gateways and IP ranges as we go, and then lets the
+// user toggle its flags before creating it. If what the user enters for a
+// setting isn't valid we ask for that setting again.
+func (gui *Gui) handleNetworksCreate(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.Tr.NetworkNameTitle, func(g *gocui.Gui, v *gocui.View) error {
+		options := &commands.NetworkCreateOptions{Name: gui.trimmedContent(v)}
+		if options.Name == "" {
+			return nil
+		}
+
+		return gui.createPromptPanelWithInitialContent(gui.Tr.NetworkDriverTitle, "bridge", func(g *gocui.Gui, v *gocui.View) error {
+			options.Driver = gui.trimmedContent(v)
+
+			withSubnets := func(content string) commands.NetworkCreateOptions {
+				result := *options
+				result.Subnets = commands.ParseList(content)
+				// an IPv6 subnet only makes sense with IPv6 enabled, so we assume that's
+				// what the user wants. They can still turn it off before creating.
+				result.EnableIPv6 = commands.HasIPv6Subnet(result.Subnets)
+				return result
+			}
+			validateSubnets := func(content string) error {
+				return validateNetworkIPAM(withSubnets(content))
+			}
+
+			return gui.createValidatedPromptPanel(gui.Tr.SubnetsTitle, "", validateSubnets, func(content string) error {
+				*options = withSubnets(content)
+
+				validateGateways := func(content string) error {
+					result := *options
+					result.Gateways = commands.ParseList(content)
+					return validateNetworkIPAM(result)
+				}
+
+				return gui.createValidatedPromptPanel(gui.Tr.GatewaysTitle, "", validateGateways, func(content string) error {
+					options.Gateways = commands.ParseList(content)
+
+					validateIPRanges := func(content string) error {
+						result := *options
+						result.IPRanges = commands.ParseList(content)
+						return validateNetworkIPAM(result)
+					}
+
+					return gui.createValidatedPromptPanel(gui.Tr.IPRangesTitle, "", validateIPRanges, func(content string) error {
+						options.IPRanges = commands.ParseList(content)
+
+						return gui.createValidatedPromptPanel(gui.Tr.LabelsTitle, "", validateKeyValues, func(content string) error {
+							options.Labels, _ = commands.ParseKeyValues(content)
+
+							return gui.networkCreateMenu(options, 0)
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+// validateNetworkIPAM checks what the user's entered so far for the network's
+// subnets, gateways and IP ranges
+func validateNetworkIPAM(options commands.NetworkCreateOptions) error {
+	_, err := commands.NetworkIPAMConfig(options.Subnets, options.Gateways, options.IPRanges, options.EnableIPv6)
+	return err
+}
+
+// validateKeyValues checks that the user entered a list of key=value pairs,
+// e.g. for labels
+func validateKeyValues(content string) error {
+	_, err := commands.ParseKeyValues(content)
+	return err
+}
+
+// networkCreateMenu lets the user toggle the new network's flags before
+// creating it
+func (gui *Gui) networkCreateMenu(options *commands.NetworkCreateOptions, selectedIdx int) error {
+	toggle := func(value *bool) func() {
+		return func() { *value = !*value }
+	}
+	flags := []checkboxMenuItem{
+		{LabelColumns: []string{gui.Tr.NetworkInternal}, Checked: options.Internal, OnToggle: toggle(&options.Internal)},
+		{LabelColumns: []string{gui.Tr.NetworkAttachable}, Checked: options.Attachable, OnToggle: toggle(&options.Attachable)},
+		{LabelColumns: []string{gui.Tr.NetworkEnableIPv6}, Checked: options.EnableIPv6, OnToggle: toggle(&options.EnableIPv6)},
+	}
+
+	action := &types.MenuItem{
+		Label: gui.Tr.CreateNetwork,
+		OnPress: func() error {
+			return gui.createNetwork(*options)
+		},
+	}
+
+	title := utils.ResolvePlaceholderString(gui.Tr.CreateNetworkTitle, map[string]string{"name": options.Name})
+	return gui.checkboxMenu(title, action, flags, selectedIdx, func(selectedIdx int) error {
+		return gui.networkCreateMenu(options, selectedIdx)
+	})
+}
+
+func (gui *Gui) createNetwork(options commands.NetworkCreateOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.CreatingStatus, func() error {
+		id, err := gui.DockerCommand.CreateNetwork(options)
+		if err != nil {
+			return err
+		}
+
+		return selectListItem(gui, gui.Panels.Networks, gui.refreshStateNetworks, func(network *commands.Network) bool {
+			return network.Network.ID == id
+		})
+	})
+}
@@ -0,0 +1,145 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+func (gui *Gui) renderNetworkTopology(network *commands.Network) tasks.TaskFunc {
+	return gui.NewSimpleRenderStringTask(func() string {
+		topology := commands.BuildNetworkTopology(gui.Panels.Networks.List.GetAllItems(), gui.Panels.Containers.List.GetAllItems(), false)
+		return gui.networkTopologyStr(topology, network.Name)
+	})
+}
+
+func (gui *Gui) renderProjectNetworkTopology(project *commands.Project) tasks.TaskFunc {
+	return gui.NewSimpleRenderStringTask(func() string {
+		containers := lo.Filter(gui.Panels.Containers.List.GetAllItems(), func(ctr *commands.Container, _ int) bool {
+			return ctr.ProjectName == project.Name
+		})
+		topology := commands.BuildNetworkTopology(gui.Panels.Networks.List.GetAllItems(), containers, true)
+		return gui.networkTopologyStr(topology, "")
+	})
+}
+
+// networkTopologyStr draws the ports published on the host, each network with
+// the containers on it, and which containers can reach which. The network
+// with the given name, if any, is highlighted.
+func (gui *Gui) networkTopologyStr(topology *commands.NetworkTopology, selectedNetwork string) string {
+	sections := []string{gui.publishedPortsStr(topology.PublishedPorts)}
+
+	if len(topology.Networks) == 0 {
+		sections = append(sections, gui.Tr.NoNetworks)
+	}
+	for _, network := range topology.Networks {
+		sections = append(sections, gui.topologyNetworkStr(network, network.Network.Name == selectedNetwork))
+	}
+
+	sections = append(sections, gui.reachabilityStr(topology.Peers()))
+
+	return strings.Join(sections, "\n\n")
+}
+
+func (gui *Gui) publishedPortsStr(ports []*commands.PublishedPort) string {
+	title := utils.ColoredStringDirect(gui.Tr.HostTitle, color.New(color.FgBlue, color.Bold))
+	if len(ports) == 0 {
+		return title + "\n" + treeLines([]string{utils.ColoredString(gui.Tr.NoPublishedPorts, color.FgWhite)})
+	}
+
+	rows := lo.Map(ports, func(port *commands.PublishedPort, _ int) []string {
+		host := port.Port.IP
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		return []string{
+			fmt.Sprintf("%s:%d", host, port.Port.PublicPort),
+			"─▶",
+			utils.ColoredString(port.Container.Name, color.FgYellow) + fmt.Sprintf(":%d/%s", port.Port.PrivatePort, port.Port.Type),
+		}
+	})
+
+	return title + "\n" + gui.treeTable(rows)
+}
+
+func (gui *Gui) topologyNetworkStr(network *commands.TopologyNetwork, selected bool) string {
+	details := []string{network.Network.Network.Driver}
+	if network.Network.Network.Internal {
+		details = append(details, gui.Tr.NetworkInternalShort)
+	}
+
+	title := utils.ColoredStringDirect(network.Network.Name, color.New(color.FgCyan, color.Bold))
+	if selected {
+		title = utils.ColoredStringDirect(network.Network.Name, color.New(color.FgGreen, color.Bold))
+	}
+	title += " (" + strings.Join(details, ", ") + ")"
+
+	if len(network.Endpoints) == 0 {
+		return title + "\n" + treeLines([]string{utils.ColoredString(gui.Tr.NoContainersOnNetwork, color.FgWhite)})
+	}
+
+	rows := lo.Map(network.Endpoints, func(endpoint *commands.NetworkEndpoint, _ int) []string {
+		return []string{
+			utils.ColoredString(endpoint.Container.Name, color.FgYellow),
+			endpoint.Settings.IPAddress,
+			endpoint.Settings.GlobalIPv6Address,
+		}
+	})
+
+	return title + "\n" + gui.treeTable(rows)
+}
+
+// reachabilityStr lists, for each container, the containers it shares a
+// network with along with the networks they share
+func (gui *Gui) reachabilityStr(peers []*commands.ContainerPeers) string {
+	title := utils.ColoredStringDirect(gui.Tr.ReachabilityTitle, color.New(color.FgBlue, color.Bold))
+	if len(peers) == 0 {
+		return title + "\n" + treeLines([]string{utils.ColoredString(gui.Tr.NoContainersOnNetworks, color.FgWhite)})
+	}
+
+	rows := lo.Map(peers, func(peers *commands.ContainerPeers, _ int) []string {
+		names := lo.Keys(peers.Peers)
+		sort.Strings(names)
+
+		reachable := lo.Map(names, func(name string, _ int) string {
+			return utils.ColoredString(name, color.FgYellow) + " (" + strings.Join(peers.Peers[name], ", ") + ")"
+		})
+		if len(reachable) == 0 {
+			reachable = []string{utils.ColoredString(gui.Tr.ReachesNoContainers, color.FgWhite)}
+		}
+
+		return []string{utils.ColoredString(peers.Container.Name, color.FgYellow), "─▶", strings.Join(reachable, ", ")}
+	})
+
+	return title + "\n" + gui.treeTable(rows)
+}
+
+// treeTable renders the rows as an aligned table whose lines hang off a tree
+func (gui *Gui) treeTable(rows [][]string) string {
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+		return ""
+	}
+
+	return treeLines(strings.Split(table, "\n"))
+}
+
+func treeLines(lines []string) string {
+	output := make([]string, len(lines))
+	for i, line := range lines {
+		branch := "├── "
+		if i == len(lines)-1 {
+			branch = "└── "
+		}
+		output[i] = branch + line
+	}
+
+	return strings.Join(output, "\n")
+}
@@ -2,6 +2,7 @@ package gui
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
@@ -25,10 +26,20 @@ func (gui *Gui) getNetworksPanel() *panels.SideListPanel[*commands.Network] {
 						Title:  gui.Tr.ConfigTitle,
 						Render: gui.renderNetworkConfig,
 					},
+					{
+						Key:    "topology",
+						Title:  gui.Tr.TopologyTitle,
+						Render: gui.renderNetworkTopology,
+					},
 				}
 			},
 			GetItemContextCacheKey: func(network *commands.Network) string {
-				return "networks-" + network.Name
+				// the config tab shows the containers on the network, so we
+				// re-render it when they come and go
+				endpoints := network.Endpoints(gui.Panels.Containers.List.GetAllItems())
+				return "networks-" + network.Name + "-" + strings.Join(lo.Map(endpoints, func(endpoint *commands.NetworkEndpoint, _ int) string {
+					return endpoint.Settings.EndpointID
+				}), ",")
 			},
 		},
 		ListPanel: panels.ListPanel[*commands.Network]{
@@ -47,6 +58,9 @@ func (gui *Gui) getNetworksPanel() *panels.SideListPanel[*commands.Network] {
 		Hide: func() bool {
 			return gui.State.UIMode != MODE_RESSOURCES
 		},
+		GetItemKey: func(network *commands.Network) string {
+			return network.Network.ID
+		},
 	}
 }
 
@@ -66,15 +80,11 @@ func (gui *Gui) networkConfigStr(network *commands.Network) string {
 	output += utils.WithPadding("Attachable: ", padding) + strconv.FormatBool(network.Network.Attachable) + "\n"
 	output += utils.WithPadding("Ingress: ", padding) + strconv.FormatBool(network.Network.Ingress) + "\n"
 
+	endpoints := network.Endpoints(gui.Panels.Containers.List.GetAllItems())
 	output += utils.WithPadding("Containers: ", padding)
-	if len(network.Network.Containers) > 0 {
-		output += "\n"
-		for _, v := range network.Network.Containers {
-			output += utils.FormatMapItem(padding, v.Name, v.EndpointID)
-		}
-	} else {
-		output += "none\n"
-	}
+	output += gui.networkEndpointsStr(padding, gui.Tr.ContainerColumn, endpoints, func(endpoint *commands.NetworkEndpoint) string {
+		return endpoint.Container.Name
+	})
 
 	output += "\n"
 	output += utils.WithPadding("Labels: ", padding) + utils.FormatMap(padding, network.Network.Labels) + "\n"
@@ -103,6 +113,10 @@ func (gui *Gui) refreshStateNetworks() error {
 }
 
 func (gui *Gui) handleNetworksRemoveMenu(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Networks.MarkedItems(); len(marked) > 0 {
+		return gui.batchNetworkRemoveMenu(marked)
+	}
+
 	network, err := gui.Panels.Networks.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -140,19 +154,11 @@ func (gui *Gui) handleNetworksRemoveMenu(g *gocui.Gui, v *gocui.View) error {
 	})
 }
 
-func (gui *Gui) handlePruneNetworks() error {
-	return gui.createConfirmationPanel(gui.Tr.Confirm, gui.Tr.ConfirmPruneNetworks, func(g *gocui.Gui, v *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
-			err := gui.DockerCommand.PruneNetworks()
-			if err != nil {
-				return gui.createErrorPanel(err.Error())
-			}
-			return nil
-		})
-	}, nil)
-}
-
 func (gui *Gui) handleNetworksCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Networks.MarkedItems(); len(marked) > 0 {
+		return gui.batchNetworksCustomCommand(marked)
+	}
+
 	network, err := gui.Panels.Networks.GetSelectedItem()
 	if err != nil {
 		return nil
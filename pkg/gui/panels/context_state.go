@@ -28,6 +28,11 @@ type MainTab[T any] struct {
 	Title string
 	// function to render the content of the tab
 	Render func(item T) tasks.TaskFunc
+	// optional: anything else the tab's content depends on, which is added to
+	// the context cache key while the tab is shown. Unlike what
+	// GetItemContextCacheKey returns, it doesn't re-render the other tabs
+	// when it changes.
+	GetContextCacheKey func(item T) string
 }
 
 func (self *ContextState[T]) GetMainTabTitles() []string {
@@ -37,7 +42,12 @@ func (self *ContextState[T]) GetMainTabTitles() []string {
 }
 
 func (self *ContextState[T]) GetCurrentContextKey(item T) string {
-	return self.GetItemContextCacheKey(item) + "-" + self.GetCurrentMainTab().Key
+	tab := self.GetCurrentMainTab()
+	key := self.GetItemContextCacheKey(item) + "-" + tab.Key
+	if tab.GetContextCacheKey != nil {
+		key += "-" + tab.GetContextCacheKey(item)
+	}
+	return key
 }
 
 func (self *ContextState[T]) GetCurrentMainTab() MainTab[T] {
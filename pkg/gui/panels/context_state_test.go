@@ -0,0 +1,31 @@
+package panels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextStateTabCacheKey(t *testing.T) {
+	extra := "running"
+	state := &ContextState[string]{
+		GetMainTabs: func() []MainTab[string] {
+			return []MainTab[string]{
+				{Key: "files"},
+				{Key: "containers", GetContextCacheKey: func(item string) string { return extra }},
+			}
+		},
+		GetItemContextCacheKey: func(item string) string { return "volumes-" + item },
+	}
+
+	assert.Equal(t, "volumes-data-files", state.GetCurrentContextKey("data"))
+
+	state.HandleNextMainTab()
+	assert.Equal(t, "volumes-data-containers-running", state.GetCurrentContextKey("data"))
+
+	// only the tab that asked for it sees the change
+	extra = "exited"
+	assert.Equal(t, "volumes-data-containers-exited", state.GetCurrentContextKey("data"))
+	state.HandlePrevMainTab()
+	assert.Equal(t, "volumes-data-files", state.GetCurrentContextKey("data"))
+}
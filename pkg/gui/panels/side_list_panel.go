@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
 	"github.com/peauc/lazydocker-ng/pkg/tasks"
@@ -25,6 +26,10 @@ type ISideListPanel interface {
 	HandleClick() error
 	HandlePrevMainTab() error
 	HandleNextMainTab() error
+	CanMark() bool
+	HandleToggleMark() error
+	HandleToggleRange() error
+	ClearMarks() bool
 }
 
 // list panel at the side of the screen that renders content to the main panel
@@ -59,6 +64,19 @@ type SideListPanel[T comparable] struct {
 
 	// This can be nil if you want to always show the panel
 	Hide func() bool
+
+	// returns a key identifying the item, which stays the same when the items
+	// are refreshed. Items can only be marked (to act on several at once) if
+	// this is set.
+	GetItemKey func(T) string
+
+	// the keys of the marked items
+	markedKeys map[string]bool
+	// whether we're marking a range of items (like vim's visual mode), and the
+	// key of the item the range started at. We go by its key rather than its
+	// index as refreshing the list may move it.
+	rangeActive   bool
+	rangeStartKey string
 }
 
 var _ ISideListPanel = &SideListPanel[int]{}
@@ -80,6 +98,9 @@ type IGui interface {
 func (self *SideListPanel[T]) HandleClick() error {
 	itemCount := self.List.Len()
 	handleSelect := self.HandleSelect
+	if self.rangeActive {
+		handleSelect = self.RerenderList
+	}
 	selectedLine := &self.SelectedIdx
 
 	if err := self.Gui.HandleClick(self.View, itemCount, selectedLine, handleSelect); err != nil {
@@ -153,12 +174,22 @@ func (self *SideListPanel[T]) GetSelectedItem() (T, error) {
 func (self *SideListPanel[T]) HandleNextLine() error {
 	self.SelectNextLine()
 
+	// the range being marked follows the selection
+	if self.rangeActive {
+		return self.RerenderList()
+	}
+
 	return self.HandleSelect()
 }
 
 func (self *SideListPanel[T]) HandlePrevLine() error {
 	self.SelectPrevLine()
 
+	// the range being marked follows the selection
+	if self.rangeActive {
+		return self.RerenderList()
+	}
+
 	return self.HandleSelect()
 }
 
@@ -226,8 +257,20 @@ func (self *SideListPanel[T]) RerenderList() error {
 
 	self.Gui.Update(func() error {
 		self.View.Clear()
+		hasMarks := self.HasMarks()
+		isMarked := self.isMarkedFunc()
 		table := lo.Map(self.List.GetItems(), func(item T, index int) []string {
-			return self.GetTableCells(item)
+			cells := self.GetTableCells(item)
+			// we only make room for the marker while there are marked items, so
+			// that the list doesn't shift around otherwise
+			if hasMarks {
+				marker := " "
+				if isMarked(item, index) {
+					marker = utils.ColoredString("●", color.FgMagenta)
+				}
+				cells = append([]string{marker}, cells...)
+			}
+			return cells
 		})
 		renderedTable, err := utils.RenderTable(table)
 		if err != nil {
@@ -269,3 +312,135 @@ func (self *SideListPanel[T]) IsHidden() bool {
 
 	return self.Hide()
 }
+
+func (self *SideListPanel[T]) CanMark() bool {
+	return self.GetItemKey != nil
+}
+
+// ToggleMark marks the selected item, or unmarks it if it's already marked.
+// If we're marking a range, this marks the range instead.
+func (self *SideListPanel[T]) ToggleMark() {
+	if !self.CanMark() {
+		return
+	}
+
+	if self.rangeActive {
+		self.commitRange()
+		return
+	}
+
+	item, ok := self.List.TryGet(self.SelectedIdx)
+	if !ok {
+		return
+	}
+
+	if self.markedKeys == nil {
+		self.markedKeys = map[string]bool{}
+	}
+	key := self.GetItemKey(item)
+	if self.markedKeys[key] {
+		delete(self.markedKeys, key)
+	} else {
+		self.markedKeys[key] = true
+	}
+}
+
+// ToggleRange starts marking a range of items from the selected one, such that
+// moving the selection marks everything in between. Toggling it again marks
+// the range.
+func (self *SideListPanel[T]) ToggleRange() {
+	if !self.CanMark() {
+		return
+	}
+
+	if self.rangeActive {
+		self.commitRange()
+		return
+	}
+
+	item, ok := self.List.TryGet(self.SelectedIdx)
+	if !ok {
+		return
+	}
+
+	self.rangeActive = true
+	self.rangeStartKey = self.GetItemKey(item)
+}
+
+func (self *SideListPanel[T]) commitRange() {
+	if self.markedKeys == nil {
+		self.markedKeys = map[string]bool{}
+	}
+	if first, last, ok := self.rangeBounds(); ok {
+		for _, item := range self.List.GetItems()[first : last+1] {
+			self.markedKeys[self.GetItemKey(item)] = true
+		}
+	}
+
+	self.rangeActive = false
+}
+
+// rangeBounds returns the indices of the first and last items of the range
+// being marked, which runs from the item it started at to the selected one.
+// If that item's no longer listed, there's no range.
+func (self *SideListPanel[T]) rangeBounds() (int, int, bool) {
+	if !self.rangeActive {
+		return 0, 0, false
+	}
+
+	items := self.List.GetItems()
+	_, start, found := lo.FindIndexOf(items, func(item T) bool {
+		return self.GetItemKey(item) == self.rangeStartKey
+	})
+	if !found {
+		return 0, 0, false
+	}
+
+	selected := min(self.SelectedIdx, len(items)-1)
+	return min(start, selected), max(start, selected), true
+}
+
+// isMarkedFunc returns a function telling us whether the given item, at the
+// given index in the list, is marked. We only work out the range once.
+func (self *SideListPanel[T]) isMarkedFunc() func(item T, index int) bool {
+	first, last, inRange := self.rangeBounds()
+
+	return func(item T, index int) bool {
+		if inRange && index >= first && index <= last {
+			return true
+		}
+		return self.CanMark() && self.markedKeys[self.GetItemKey(item)]
+	}
+}
+
+// MarkedItems returns the marked items that are in the list as it's filtered,
+// including those in the range being marked, in the order they're listed
+func (self *SideListPanel[T]) MarkedItems() []T {
+	return lo.Filter(self.List.GetItems(), self.isMarkedFunc())
+}
+
+func (self *SideListPanel[T]) HasMarks() bool {
+	return len(self.MarkedItems()) > 0
+}
+
+// ClearMarks unmarks every item, returning whether any were marked
+func (self *SideListPanel[T]) ClearMarks() bool {
+	hadMarks := self.HasMarks()
+
+	self.markedKeys = nil
+	self.rangeActive = false
+
+	return hadMarks
+}
+
+func (self *SideListPanel[T]) HandleToggleMark() error {
+	self.ToggleMark()
+
+	return self.RerenderList()
+}
+
+func (self *SideListPanel[T]) HandleToggleRange() error {
+	self.ToggleRange()
+
+	return self.RerenderList()
+}
@@ -0,0 +1,102 @@
+package panels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMarkablePanel(items []string) *SideListPanel[string] {
+	panel := &SideListPanel[string]{
+		ListPanel:  ListPanel[string]{List: NewFilteredList[string]()},
+		GetItemKey: func(item string) string { return item },
+	}
+	panel.List.SetItems(items)
+	return panel
+}
+
+func TestSideListPanelToggleMark(t *testing.T) {
+	panel := newMarkablePanel([]string{"a", "b", "c", "d"})
+	assert.False(t, panel.HasMarks())
+
+	panel.ToggleMark()
+	panel.SetSelectedLineIdx(2)
+	panel.ToggleMark()
+	assert.Equal(t, []string{"a", "c"}, panel.MarkedItems())
+
+	panel.ToggleMark()
+	assert.Equal(t, []string{"a"}, panel.MarkedItems())
+
+	// marks survive the items being refreshed
+	panel.List.SetItems([]string{"d", "c", "b", "a"})
+	assert.Equal(t, []string{"a"}, panel.MarkedItems())
+
+	// marked items that are filtered out don't count
+	panel.List.Filter(func(item string, _ int) bool { return item != "a" })
+	assert.Empty(t, panel.MarkedItems())
+	assert.False(t, panel.HasMarks())
+}
+
+func TestSideListPanelToggleRange(t *testing.T) {
+	panel := newMarkablePanel([]string{"a", "b", "c", "d", "e"})
+
+	panel.SetSelectedLineIdx(3)
+	panel.ToggleRange()
+	assert.Equal(t, []string{"d"}, panel.MarkedItems())
+
+	// the range follows the selection, in either direction
+	panel.SetSelectedLineIdx(1)
+	assert.Equal(t, []string{"b", "c", "d"}, panel.MarkedItems())
+
+	panel.ToggleRange()
+	panel.SetSelectedLineIdx(4)
+	assert.Equal(t, []string{"b", "c", "d"}, panel.MarkedItems())
+
+	// marking while marking a range marks the range
+	panel.ToggleRange()
+	panel.SelectNextLine()
+	panel.SetSelectedLineIdx(0)
+	panel.ToggleMark()
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, panel.MarkedItems())
+
+	assert.True(t, panel.ClearMarks())
+	assert.Empty(t, panel.MarkedItems())
+	assert.False(t, panel.ClearMarks())
+}
+
+func TestSideListPanelToggleRangeRefresh(t *testing.T) {
+	panel := newMarkablePanel([]string{"a", "b", "c", "d", "e"})
+
+	panel.SetSelectedLineIdx(1)
+	panel.ToggleRange()
+	panel.SetSelectedLineIdx(2)
+	assert.Equal(t, []string{"b", "c"}, panel.MarkedItems())
+
+	// the list is refreshed and re-sorted, e.g. because a container stopped.
+	// The range still starts at the item it started at, and runs to whatever
+	// is now selected.
+	panel.List.SetItems([]string{"e", "d", "c", "b", "a"})
+	assert.Equal(t, []string{"c", "b"}, panel.MarkedItems())
+
+	panel.SetSelectedLineIdx(4)
+	panel.ToggleMark()
+	assert.Equal(t, []string{"b", "a"}, panel.MarkedItems())
+
+	// if the item the range started at goes away, there's no range
+	panel.ClearMarks()
+	panel.SetSelectedLineIdx(0)
+	panel.ToggleRange()
+	panel.SetSelectedLineIdx(1)
+	panel.List.SetItems([]string{"d", "c", "b", "a"})
+	assert.Empty(t, panel.MarkedItems())
+}
+
+func TestSideListPanelCannotMark(t *testing.T) {
+	panel := newMarkablePanel([]string{"a", "b"})
+	panel.GetItemKey = nil
+
+	panel.ToggleMark()
+	panel.ToggleRange()
+	assert.False(t, panel.CanMark())
+	assert.Empty(t, panel.MarkedItems())
+}
@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/fatih/color"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+type diffNode struct {
+	name     string
+	change   *container.ChangeType
+	children map[string]*diffNode
+}
+
+// CountContainerDiff returns the number of added, changed and deleted paths in
+// a container's filesystem diff
+func CountContainerDiff(changes []container.FilesystemChange) (int, int, int) {
+	added, changed, deleted := 0, 0, 0
+	for _, change := range changes {
+		switch change.Kind {
+		case container.ChangeAdd:
+			added++
+		case container.ChangeModify:
+			changed++
+		case container.ChangeDelete:
+			deleted++
+		}
+	}
+	return added, changed, deleted
+}
+
+// RenderContainerDiff renders the changes to a container's filesystem as a
+// tree, with added paths in green, changed paths in yellow and deleted paths
+// in red
+func RenderContainerDiff(changes []container.FilesystemChange) string {
+	root := &diffNode{children: map[string]*diffNode{}}
+
+	for _, change := range changes {
+		node := root
+		for _, name := range strings.Split(strings.Trim(change.Path, "/"), "/") {
+			child, ok := node.children[name]
+			if !ok {
+				child = &diffNode{name: name, children: map[string]*diffNode{}}
+				node.children[name] = child
+			}
+			node = child
+		}
+		kind := change.Kind
+		node.change = &kind
+	}
+
+	lines := []string{}
+	var walk func(node *diffNode, depth int)
+	walk = func(node *diffNode, depth int) {
+		names := make([]string, 0, len(node.children))
+		for name := range node.children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			child := node.children[name]
+			lines = append(lines, strings.Repeat("  ", depth)+child.displayString())
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	return strings.Join(lines, "\n")
+}
+
+func (n *diffNode) displayString() string {
+	name := n.name
+	if len(n.children) > 0 {
+		name += "/"
+	}
+
+	if n.change == nil {
+		return "  " + name
+	}
+
+	colour := color.FgYellow
+	marker := "C"
+	switch *n.change {
+	case container.ChangeAdd:
+		colour, marker = color.FgGreen, "A"
+	case container.ChangeDelete:
+		colour, marker = color.FgRed, "D"
+	}
+
+	return utils.ColoredString(marker+" "+name, colour)
+}
@@ -0,0 +1,92 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderContainerDiff is a function.
+func TestRenderContainerDiff(t *testing.T) {
+	type scenario struct {
+		name     string
+		changes  []container.FilesystemChange
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"no changes",
+			nil,
+			"",
+		},
+		{
+			"one of each kind",
+			[]container.FilesystemChange{
+				{Path: "/tmp", Kind: container.ChangeModify},
+				{Path: "/tmp/new.txt", Kind: container.ChangeAdd},
+				{Path: "/etc/old.conf", Kind: container.ChangeDelete},
+			},
+			// parents docker doesn't report as changed are listed without a marker,
+			// and directories are listed before their contents in name order
+			"  etc/\n" +
+				"  D old.conf\n" +
+				"C tmp/\n" +
+				"  A new.txt",
+		},
+		{
+			"nested directories",
+			[]container.FilesystemChange{
+				{Path: "/var/lib/app/data.db", Kind: container.ChangeAdd},
+				{Path: "/var/log", Kind: container.ChangeModify},
+			},
+			"  var/\n" +
+				"    lib/\n" +
+				"      app/\n" +
+				"      A data.db\n" +
+				"  C log",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, utils.Decolorise(RenderContainerDiff(s.changes)))
+		})
+	}
+}
+
+// TestCountContainerDiff is a function.
+func TestCountContainerDiff(t *testing.T) {
+	type scenario struct {
+		name            string
+		changes         []container.FilesystemChange
+		expectedAdded   int
+		expectedChanged int
+		expectedDeleted int
+	}
+
+	scenarios := []scenario{
+		{"no changes", nil, 0, 0, 0},
+		{
+			"mixed changes",
+			[]container.FilesystemChange{
+				{Path: "/tmp", Kind: container.ChangeModify},
+				{Path: "/tmp/a", Kind: container.ChangeAdd},
+				{Path: "/tmp/b", Kind: container.ChangeAdd},
+				{Path: "/etc/c", Kind: container.ChangeDelete},
+			},
+			2, 1, 1,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			added, changed, deleted := CountContainerDiff(s.changes)
+			assert.EqualValues(t, s.expectedAdded, added)
+			assert.EqualValues(t, s.expectedChanged, changed)
+			assert.EqualValues(t, s.expectedDeleted, deleted)
+		})
+	}
+}
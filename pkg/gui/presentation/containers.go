@@ -10,23 +10,30 @@ import (
 	"github.com/fatih/color"
 	"github.com/peauc/lazydocker-ng/pkg/commands"
 	"github.com/peauc/lazydocker-ng/pkg/config"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
 	"github.com/peauc/lazydocker-ng/pkg/utils"
 	"github.com/samber/lo"
 )
 
-func GetContainerDisplayStrings(guiConfig *config.GuiConfig, container *commands.Container) []string {
+func GetContainerDisplayStrings(tr *i18n.TranslationSet, guiConfig *config.GuiConfig, container *commands.Container, update *commands.ImageUpdate) []string {
 	return []string{
 		getContainerDisplayStatus(guiConfig, container),
 		getContainerDisplaySubstatus(guiConfig, container),
 		container.Name,
 		getDisplayCPUPerc(container),
 		utils.ColoredString(displayPorts(container), color.FgYellow),
-		utils.ColoredString(displayContainerImage(container), color.FgMagenta),
+		displayContainerImage(tr, container, update),
 	}
 }
 
-func displayContainerImage(container *commands.Container) string {
-	return strings.TrimPrefix(container.Container.Image, "sha256:")
+// displayContainerImage shows the container's image, marking it if the
+// registry has a newer version of it
+func displayContainerImage(tr *i18n.TranslationSet, container *commands.Container, update *commands.ImageUpdate) string {
+	image := utils.ColoredString(strings.TrimPrefix(container.Container.Image, "sha256:"), color.FgMagenta)
+	if update != nil && update.Available {
+		image += " " + utils.ColoredString(tr.UpdateAvailableBadge, color.FgGreen)
+	}
+	return image
 }
 
 func displayPorts(c *commands.Container) string {
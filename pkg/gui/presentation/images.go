@@ -1,14 +1,91 @@
 package presentation
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
 	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
 	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
 )
 
-func GetImageDisplayStrings(image *commands.Image) []string {
+func GetImageDisplayStrings(tr *i18n.TranslationSet, image *commands.Image, containers []*commands.Container, report *commands.VulnerabilityReport) []string {
+	tag := image.Tag
+	// we only show the first tag, so we let the user know there are more
+	if tags := image.RepoTags(); len(tags) > 1 {
+		tag += utils.ColoredString(fmt.Sprintf(" (+%d)", len(tags)-1), color.FgBlue)
+	}
+
 	return []string{
 		image.Name,
-		image.Tag,
+		tag,
 		utils.FormatDecimalBytes(int(image.Image.Size)),
+		displayContainerUsage(tr, containers),
+		displayVulnerabilityBadge(tr, report),
+	}
+}
+
+// displayContainerUsage shows how many running and stopped containers use an
+// image or volume
+func displayContainerUsage(tr *i18n.TranslationSet, containers []*commands.Container) string {
+	if len(containers) == 0 {
+		return ""
+	}
+
+	running := lo.CountBy(containers, func(ctr *commands.Container) bool {
+		return ctr.Container.State == "running"
+	})
+
+	parts := []string{}
+	if running > 0 {
+		parts = append(parts, utils.ColoredString(utils.ResolvePlaceholderString(tr.RunningContainersCount, map[string]string{"count": fmt.Sprint(running)}), color.FgGreen))
+	}
+	if stopped := len(containers) - running; stopped > 0 {
+		parts = append(parts, utils.ColoredString(utils.ResolvePlaceholderString(tr.StoppedContainersCount, map[string]string{"count": fmt.Sprint(stopped)}), color.FgYellow))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// displayVulnerabilityBadge shows how many vulnerabilities of the highest
+// severity scanning the image found, if we've scanned it
+func displayVulnerabilityBadge(tr *i18n.TranslationSet, report *commands.VulnerabilityReport) string {
+	if report == nil {
+		return ""
+	}
+
+	if len(report.Vulnerabilities) == 0 {
+		return utils.ColoredString(tr.NoCVEsBadge, color.FgGreen)
+	}
+
+	// the report's vulnerabilities may not be sorted by severity
+	counts := report.Counts()
+	highest, _ := lo.Find(commands.Severities, func(severity string) bool {
+		return counts[severity] > 0
+	})
+
+	badge := fmt.Sprintf("%d %s", counts[highest], strings.ToLower(highest))
+	if others := len(report.Vulnerabilities) - counts[highest]; others > 0 {
+		badge += fmt.Sprintf(" (+%d)", others)
+	}
+
+	return utils.ColoredString(badge, SeverityColor(highest))
+}
+
+// SeverityColor returns the colour we show a vulnerability's severity in
+func SeverityColor(severity string) color.Attribute {
+	switch severity {
+	case commands.SeverityCritical:
+		return color.FgRed
+	case commands.SeverityHigh:
+		return color.FgMagenta
+	case commands.SeverityMedium:
+		return color.FgYellow
+	case commands.SeverityLow:
+		return color.FgBlue
+	default:
+		return color.FgWhite
 	}
 }
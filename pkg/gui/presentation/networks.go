@@ -1,7 +1,43 @@
 package presentation
 
-import "github.com/peauc/lazydocker-ng/pkg/commands"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/samber/lo"
+)
 
 func GetNetworkDisplayStrings(network *commands.Network) []string {
 	return []string{network.Network.Driver, network.Name}
 }
+
+// GetNetworkEndpointDisplayStrings returns the cells describing a container's
+// connection to a network: the given name (the container's or the network's,
+// depending on which side we're looking from) followed by the container's
+// addresses on the network and its aliases there
+func GetNetworkEndpointDisplayStrings(name string, endpoint *commands.NetworkEndpoint) []string {
+	settings := endpoint.Settings
+
+	// a stopped container has no addresses, but it may have static ones
+	// it'll get once it's started
+	ipv4, ipv6 := "", ""
+	if settings.IPAMConfig != nil {
+		ipv4, ipv6 = settings.IPAMConfig.IPv4Address, settings.IPAMConfig.IPv6Address
+	}
+	if settings.IPAddress != "" {
+		ipv4 = fmt.Sprintf("%s/%d", settings.IPAddress, settings.IPPrefixLen)
+	}
+	if settings.GlobalIPv6Address != "" {
+		ipv6 = fmt.Sprintf("%s/%d", settings.GlobalIPv6Address, settings.GlobalIPv6PrefixLen)
+	}
+
+	// docker gives each container its short ID as an alias, which isn't one
+	// the user chose
+	id := endpoint.Container.ID
+	aliases := lo.Filter(settings.Aliases, func(alias string, _ int) bool {
+		return len(id) < 12 || alias != id[:12]
+	})
+
+	return []string{name, ipv4, ipv6, settings.MacAddress, strings.Join(aliases, ", ")}
+}
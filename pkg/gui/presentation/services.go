@@ -4,10 +4,11 @@ import (
 	"github.com/fatih/color"
 	"github.com/peauc/lazydocker-ng/pkg/commands"
 	"github.com/peauc/lazydocker-ng/pkg/config"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
 	"github.com/peauc/lazydocker-ng/pkg/utils"
 )
 
-func GetServiceDisplayStrings(guiConfig *config.GuiConfig, service *commands.Service) []string {
+func GetServiceDisplayStrings(tr *i18n.TranslationSet, guiConfig *config.GuiConfig, service *commands.Service, update *commands.ImageUpdate) []string {
 	if service.Container == nil {
 		var containerState string
 		switch guiConfig.ContainerStatusHealthStyle {
@@ -38,6 +39,6 @@ func GetServiceDisplayStrings(guiConfig *config.GuiConfig, service *commands.Ser
 		service.Name,
 		getDisplayCPUPerc(container),
 		utils.ColoredString(displayPorts(container), color.FgYellow),
-		utils.ColoredString(displayContainerImage(container), color.FgMagenta),
+		displayContainerImage(tr, container, update),
 	}
 }
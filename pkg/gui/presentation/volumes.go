@@ -1,7 +1,10 @@
 package presentation
 
-import "github.com/peauc/lazydocker-ng/pkg/commands"
+import (
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/i18n"
+)
 
-func GetVolumeDisplayStrings(volume *commands.Volume) []string {
-	return []string{volume.Volume.Driver, volume.Name}
+func GetVolumeDisplayStrings(tr *i18n.TranslationSet, volume *commands.Volume, mounts []*commands.VolumeMount) []string {
+	return []string{volume.Volume.Driver, volume.Name, displayContainerUsage(tr, commands.VolumeMountContainers(mounts))}
 }
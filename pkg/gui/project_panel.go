@@ -36,6 +36,11 @@ func (gui *Gui) getProjectPanel() *panels.SideListPanel[*commands.Project] {
 							Title:  gui.Tr.DockerComposeConfigTitle,
 							Render: gui.renderDockerComposeConfig,
 						},
+						{
+							Key:    "topology",
+							Title:  gui.Tr.TopologyTitle,
+							Render: gui.renderProjectNetworkTopology,
+						},
 					}
 				}
 
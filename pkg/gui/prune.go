@@ -0,0 +1,270 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// the most containers, networks or volumes we list when previewing a prune or
+// reporting on what it removed
+const prunePreviewLimit = 20
+
+func (gui *Gui) handlePruneContainers() error {
+	return gui.promptPruneFilters(gui.Tr.ContainersNoun, true, commands.PruneOptions{}, gui.previewContainerPrune)
+}
+
+func (gui *Gui) handlePruneNetworks() error {
+	return gui.promptPruneFilters(gui.Tr.NetworksNoun, true, commands.PruneOptions{}, gui.previewNetworkPrune)
+}
+
+func (gui *Gui) handlePruneVolumes() error {
+	return gui.Menu(CreateMenuOptions{
+		Title: gui.Tr.PruneVolumes,
+		Items: []*types.MenuItem{
+			{
+				Label: gui.Tr.PruneAnonymousVolumes,
+				OnPress: func() error {
+					return gui.promptPruneFilters(gui.Tr.VolumesNoun, false, commands.PruneOptions{AnonymousOnly: true}, gui.previewVolumePrune)
+				},
+			},
+			{
+				Label: gui.Tr.PruneUnusedVolumes,
+				OnPress: func() error {
+					return gui.promptPruneFilters(gui.Tr.VolumesNoun, false, commands.PruneOptions{}, gui.previewVolumePrune)
+				},
+			},
+		},
+	})
+}
+
+// promptPruneFilters asks which containers, networks or volumes to prune by
+// age and label before previewing the prune. Docker can't prune volumes by
+// age, so we don't ask for one then.
+func (gui *Gui) promptPruneFilters(objects string, askUntil bool, options commands.PruneOptions, preview func(commands.PruneOptions) error) error {
+	promptLabels := func() error {
+		title := utils.ResolvePlaceholderString(gui.Tr.PruneObjectsLabelsTitle, map[string]string{"objects": objects})
+		return gui.createPromptPanel(title, func(g *gocui.Gui, v *gocui.View) error {
+			options.Labels = strings.Fields(gui.trimmedContent(v))
+
+			return preview(options)
+		})
+	}
+
+	if !askUntil {
+		return promptLabels()
+	}
+
+	title := utils.ResolvePlaceholderString(gui.Tr.PruneObjectsUntilTitle, map[string]string{"objects": objects})
+	return gui.createPromptPanel(title, func(g *gocui.Gui, v *gocui.View) error {
+		options.Until = gui.trimmedContent(v)
+		if options.Until != "" {
+			if _, err := commands.ParseUntil(options.Until, time.Now()); err != nil {
+				return gui.createErrorPanel(err.Error())
+			}
+		}
+
+		return promptLabels()
+	})
+}
+
+// previewContainerPrune lists the containers a prune would remove, asking for
+// confirmation before pruning
+func (gui *Gui) previewContainerPrune(options commands.PruneOptions) error {
+	containers, err := commands.ContainersToPrune(gui.Panels.Containers.List.GetAllItems(), options, time.Now())
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	if len(containers) == 0 {
+		return gui.createConfirmationPanel(gui.Tr.PruneContainers, gui.Tr.NothingToPrune, nil, nil)
+	}
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.ContainerPrunePreview, map[string]string{
+		"count": fmt.Sprint(len(containers)),
+	})
+	names := lo.Map(containers, func(ctr *commands.Container, _ int) string { return ctr.Name })
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, summary+"\n\n"+gui.pruneListStr(names, nil), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.pruneContainers(options, containers)
+	}, nil)
+}
+
+func (gui *Gui) pruneContainers(options commands.PruneOptions, containers []*commands.Container) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		report, err := gui.DockerCommand.PruneContainers(options)
+		if err != nil {
+			return err
+		}
+
+		if err := gui.refreshContainersAndServices(); err != nil {
+			return err
+		}
+
+		// the report only has the containers' IDs, so we go by the names we
+		// had for them before pruning
+		names := lo.Map(report.ContainersDeleted, func(id string, _ int) string {
+			if ctr, ok := lo.Find(containers, func(ctr *commands.Container) bool { return ctr.ID == id }); ok {
+				return ctr.Name
+			}
+			return utils.SafeTruncate(id, 12)
+		})
+
+		summary := utils.ResolvePlaceholderString(gui.Tr.ContainerPruneReport, map[string]string{
+			"count": fmt.Sprint(len(names)),
+			"size":  utils.FormatDecimalBytes(int(report.SpaceReclaimed)),
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.PruneContainers, gui.pruneReportStr(summary, names), nil, nil)
+	})
+}
+
+// previewNetworkPrune lists the networks a prune would remove, asking for
+// confirmation before pruning
+func (gui *Gui) previewNetworkPrune(options commands.PruneOptions) error {
+	networks, err := commands.NetworksToPrune(
+		gui.Panels.Networks.List.GetAllItems(),
+		gui.Panels.Containers.List.GetAllItems(),
+		options,
+		time.Now(),
+	)
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	if len(networks) == 0 {
+		return gui.createConfirmationPanel(gui.Tr.PruneNetworks, gui.Tr.NothingToPrune, nil, nil)
+	}
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.NetworkPrunePreview, map[string]string{
+		"count": fmt.Sprint(len(networks)),
+	})
+	names := lo.Map(networks, func(network *commands.Network, _ int) string { return network.Name })
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, summary+"\n\n"+gui.pruneListStr(names, nil), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.pruneNetworks(options)
+	}, nil)
+}
+
+func (gui *Gui) pruneNetworks(options commands.PruneOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		report, err := gui.DockerCommand.PruneNetworks(options)
+		if err != nil {
+			return err
+		}
+
+		if err := gui.reloadNetworks(); err != nil {
+			return err
+		}
+
+		summary := utils.ResolvePlaceholderString(gui.Tr.NetworkPruneReport, map[string]string{
+			"count": fmt.Sprint(len(report.NetworksDeleted)),
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.PruneNetworks, gui.pruneReportStr(summary, report.NetworksDeleted), nil, nil)
+	})
+}
+
+// previewVolumePrune lists the volumes a prune would remove, and how much
+// space it would reclaim as far as we know, asking for confirmation before
+// pruning
+func (gui *Gui) previewVolumePrune(options commands.PruneOptions) error {
+	volumes := commands.VolumesToPrune(
+		gui.Panels.Volumes.List.GetAllItems(),
+		gui.Panels.Containers.List.GetAllItems(),
+		options,
+	)
+
+	if len(volumes) == 0 {
+		return gui.createConfirmationPanel(gui.Tr.PruneVolumes, gui.Tr.NothingToPrune, nil, nil)
+	}
+
+	// we only know the sizes of volumes if we've asked docker for its disk
+	// usage, and docker reports -1 for those it couldn't size
+	sized := lo.Filter(volumes, func(volume *commands.Volume, _ int) bool {
+		return volume.Volume.UsageData != nil && volume.Volume.UsageData.Size >= 0
+	})
+	sizes := lo.Map(volumes, func(volume *commands.Volume, _ int) string {
+		if !lo.Contains(sized, volume) {
+			return ""
+		}
+		return utils.FormatDecimalBytes(int(volume.Volume.UsageData.Size))
+	})
+
+	summary := utils.ResolvePlaceholderString(gui.Tr.VolumePrunePreview, map[string]string{
+		"count": fmt.Sprint(len(volumes)),
+	})
+	if len(sized) > 0 {
+		summary = utils.ResolvePlaceholderString(gui.Tr.VolumePrunePreviewWithSize, map[string]string{
+			"count": fmt.Sprint(len(volumes)),
+			"size": utils.FormatDecimalBytes(int(lo.SumBy(sized, func(volume *commands.Volume) int64 {
+				return volume.Volume.UsageData.Size
+			}))),
+		})
+	}
+	names := lo.Map(volumes, func(volume *commands.Volume, _ int) string { return volume.Name })
+
+	return gui.createConfirmationPanel(gui.Tr.Confirm, summary+"\n\n"+gui.pruneListStr(names, sizes), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.pruneVolumes(options)
+	}, nil)
+}
+
+func (gui *Gui) pruneVolumes(options commands.PruneOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
+		report, err := gui.DockerCommand.PruneVolumes(options)
+		if err != nil {
+			return err
+		}
+
+		if err := gui.reloadVolumes(); err != nil {
+			return err
+		}
+
+		summary := utils.ResolvePlaceholderString(gui.Tr.VolumePruneReport, map[string]string{
+			"count": fmt.Sprint(len(report.VolumesDeleted)),
+			"size":  utils.FormatDecimalBytes(int(report.SpaceReclaimed)),
+		})
+
+		return gui.createConfirmationPanel(gui.Tr.PruneVolumes, gui.pruneReportStr(summary, report.VolumesDeleted), nil, nil)
+	})
+}
+
+// pruneListStr lists the names of the things a prune would remove, or has
+// removed, alongside their sizes if given
+func (gui *Gui) pruneListStr(names []string, sizes []string) string {
+	rows := [][]string{}
+	for i, name := range lo.Slice(names, 0, prunePreviewLimit) {
+		row := []string{name}
+		if sizes != nil {
+			row = append(row, utils.ColoredString(sizes[i], color.FgYellow))
+		}
+		rows = append(rows, row)
+	}
+
+	table, err := utils.RenderTable(rows)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+	if len(names) > prunePreviewLimit {
+		table += "\n" + utils.ResolvePlaceholderString(gui.Tr.AndMore, map[string]string{
+			"count": fmt.Sprint(len(names) - prunePreviewLimit),
+		})
+	}
+
+	return table
+}
+
+func (gui *Gui) pruneReportStr(summary string, names []string) string {
+	if len(names) == 0 {
+		return summary
+	}
+
+	return summary + ":\n\n" + gui.pruneListStr(names, nil)
+}
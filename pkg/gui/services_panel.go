@@ -74,12 +74,19 @@ func (gui *Gui) getServicesPanel() *panels.SideListPanel[*commands.Service] {
 			return a.Name < b.Name
 		},
 		GetTableCells: func(service *commands.Service) []string {
-			return presentation.GetServiceDisplayStrings(&gui.Config.UserConfig.Gui, service)
+			var update *commands.ImageUpdate
+			if service.Container != nil {
+				update = gui.DockerCommand.ImageUpdate(service.Container)
+			}
+			return presentation.GetServiceDisplayStrings(gui.Tr, &gui.Config.UserConfig.Gui, service, update)
 		},
 		Hide: func() bool {
 			// Show only in container mode AND docker compose projects
 			return gui.State.UIMode != MODE_CONTAINERS || !gui.State.InDockerComposeMode
 		},
+		GetItemKey: func(service *commands.Service) string {
+			return service.ID
+		},
 	}
 }
 
@@ -133,21 +140,23 @@ func (gui *Gui) renderServiceLogs(service *commands.Service) tasks.TaskFunc {
 }
 
 func (gui *Gui) handleServiceRemoveMenu(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServiceRemoveMenu(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
 	}
 
-	composeCommand := gui.Config.UserConfig.CommandTemplates.DockerCompose
-
 	options := []*commandOption{
 		{
 			description: gui.Tr.Remove,
-			command:     fmt.Sprintf("%s --project-name %s rm --stop --force %s", composeCommand, service.ProjectName, service.Name),
+			command:     gui.serviceRemoveCommand(service, false),
 		},
 		{
 			description: gui.Tr.RemoveWithVolumes,
-			command:     fmt.Sprintf("%s --project-name %s rm --stop --force -v %s", composeCommand, service.ProjectName, service.Name),
+			command:     gui.serviceRemoveCommand(service, true),
 		},
 	}
 
@@ -172,7 +181,22 @@ func (gui *Gui) handleServiceRemoveMenu(g *gocui.Gui, v *gocui.View) error {
 	})
 }
 
+// serviceRemoveCommand returns the command that stops and removes the
+// service's containers, and their anonymous volumes if withVolumes is true
+func (gui *Gui) serviceRemoveCommand(service *commands.Service, withVolumes bool) string {
+	flags := "--stop --force"
+	if withVolumes {
+		flags += " -v"
+	}
+
+	return fmt.Sprintf("%s --project-name %s rm %s %s", gui.Config.UserConfig.CommandTemplates.DockerCompose, service.ProjectName, flags, service.Name)
+}
+
 func (gui *Gui) handleServicePause(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServicePause(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -185,6 +209,10 @@ func (gui *Gui) handleServicePause(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleServiceStop(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServiceStop(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -217,6 +245,10 @@ func (gui *Gui) handleServiceUp(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleServiceRestart(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServiceRestart(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -232,6 +264,10 @@ func (gui *Gui) handleServiceRestart(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleServiceStart(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServiceStart(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -256,12 +292,7 @@ func (gui *Gui) handleServiceAttach(g *gocui.Gui, v *gocui.View) error {
 		return gui.createErrorPanel(gui.Tr.NoContainers)
 	}
 
-	c, err := service.Attach()
-	if err != nil {
-		return gui.createErrorPanel(err.Error())
-	}
-
-	return gui.runSubprocess(c)
+	return gui.attachToContainer(service.Container)
 }
 
 func (gui *Gui) handleServiceRenderLogsToMain(g *gocui.Gui, v *gocui.View) error {
@@ -351,6 +382,10 @@ func (gui *Gui) handleServiceRestartMenu(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleServicesCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Services.MarkedItems(); len(marked) > 0 {
+		return gui.batchServicesCustomCommand(marked)
+	}
+
 	service, err := gui.Panels.Services.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -37,6 +38,59 @@ func (gui *Gui) runSubprocessWithMessage(cmd *exec.Cmd, msg string) error {
 	return err
 }
 
+// runInTerminalWithMessage suspends the gui and gives f full control of the
+// terminal until it returns, e.g. for an attach session that we drive ourselves
+// rather than through a subprocess. An interrupt cancels the context passed to
+// f instead of killing lazydocker-ng, as the terminal may not be in raw mode.
+func (gui *Gui) runInTerminalWithMessage(f func(ctx context.Context) error, msg string) error {
+	gui.SubprocessMutex.Lock()
+	defer gui.SubprocessMutex.Unlock()
+
+	if err := gui.g.Suspend(); err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	gui.PauseBackgroundThreads = true
+
+	if msg != "" {
+		fmt.Fprintf(os.Stdout, "\n%s\n\n", utils.ColoredString(msg, color.FgGreen))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := f(ctx)
+	if err != nil {
+		gui.Log.Error(err)
+	}
+
+	gui.promptToReturn()
+
+	if err := gui.g.Resume(); err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	gui.PauseBackgroundThreads = false
+
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
+	return nil
+}
+
 func (gui *Gui) runCommandSilently(cmd *exec.Cmd) error {
 	stop := make(chan os.Signal, 1)
 	defer signal.Stop(stop)
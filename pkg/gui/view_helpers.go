@@ -469,3 +469,29 @@ func (gui *Gui) toggleMode() error {
 	}
 	return gui.switchToMode(targetMode)
 }
+
+// selectListItem refreshes a side panel's list and then focuses the panel with
+// the first item matching the predicate selected, e.g. once we've created it
+func selectListItem[T comparable](gui *Gui, panel *panels.SideListPanel[T], refresh func() error, match func(T) bool) error {
+	if err := refresh(); err != nil {
+		return err
+	}
+
+	gui.Update(func() error {
+		_, idx, found := lo.FindIndexOf(panel.List.GetItems(), match)
+		if found {
+			panel.SetSelectedLineIdx(idx)
+		}
+
+		if err := gui.switchToMode(gui.getModeForPanel(panel.GetView().Name())); err != nil {
+			return err
+		}
+		if err := gui.switchFocus(panel.GetView()); err != nil {
+			return err
+		}
+
+		return panel.RerenderList()
+	})
+
+	return nil
+}
@@ -108,6 +108,7 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.Main.Wrap = gui.Config.UserConfig.Gui.WrapMainPanel
 	// when you run a docker container with the -it flags (interactive mode) it adds carriage returns for some reason. This is not docker's fault, it's an os-level default.
 	gui.Views.Main.IgnoreCarriageReturns = true
+	gui.Views.Main.SelBgColor = selectedLineBgColor
 
 	gui.Views.ModeTabs.Frame = true
 	gui.Views.ModeTabs.Tabs = []string{"Container", "Ressources"}
@@ -0,0 +1,228 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/gui/presentation"
+	"github.com/peauc/lazydocker-ng/pkg/gui/types"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+	"github.com/samber/lo"
+)
+
+func (gui *Gui) handleVolumesBackupMenu(g *gocui.Gui, v *gocui.View) error {
+	volume, err := gui.Panels.Volumes.GetSelectedItem()
+	if err != nil {
+		return nil
+	}
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.BackupAndRestoreTitle, map[string]string{"name": volume.Name}),
+		Items: []*types.MenuItem{
+			{
+				Label:   gui.Tr.BackupVolume,
+				OnPress: func() error { return gui.promptBackupVolume(volume) },
+			},
+			{
+				Label:   gui.Tr.RestoreVolume,
+				OnPress: func() error { return gui.promptRestoreVolume(volume) },
+			},
+			{
+				Label:   gui.Tr.CopyFromVolume,
+				OnPress: func() error { return gui.promptCopyFromOtherVolume(volume) },
+			},
+			{
+				Label:   gui.Tr.CloneVolume,
+				OnPress: func() error { return gui.promptCloneVolume(volume) },
+			},
+		},
+	})
+}
+
+func (gui *Gui) promptBackupVolume(volume *commands.Volume) error {
+	initialPath := fmt.Sprintf("%s-%s.tar.gz", volume.Name, time.Now().Format("20060102-150405"))
+
+	return gui.createPromptPanelWithInitialContent(gui.Tr.BackupVolumeDestinationTitle, initialPath, func(g *gocui.Gui, v *gocui.View) error {
+		archivePath := gui.trimmedContent(v)
+		if archivePath == "" {
+			return nil
+		}
+
+		run := func() error {
+			return gui.WithProgressStatus(gui.Tr.BackingUpStatus, func(onProgress func(string)) error {
+				if err := gui.DockerCommand.BackupVolume(context.Background(), volume.Name, archivePath, bytesProgress(onProgress)); err != nil {
+					return err
+				}
+
+				return gui.volumeArchiveReport(gui.Tr.BackupVolume, gui.Tr.VolumeBackedUp, volume.Name, archivePath)
+			})
+		}
+
+		if _, err := os.Lstat(archivePath); err != nil {
+			return run()
+		}
+
+		message := utils.ResolvePlaceholderString(gui.Tr.ConfirmCopyOverwrite, map[string]string{"path": archivePath})
+		return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+			return run()
+		}, nil)
+	})
+}
+
+func (gui *Gui) promptRestoreVolume(volume *commands.Volume) error {
+	return gui.createPromptPanel(gui.Tr.RestoreVolumeSourceTitle, func(g *gocui.Gui, v *gocui.View) error {
+		archivePath := gui.trimmedContent(v)
+		if archivePath == "" {
+			return nil
+		}
+
+		info, err := os.Stat(archivePath)
+		if err != nil {
+			return gui.createErrorPanel(err.Error())
+		}
+		if info.IsDir() {
+			return gui.createErrorPanel(utils.ResolvePlaceholderString(gui.Tr.NotAnArchive, map[string]string{"path": archivePath}))
+		}
+
+		// restoring a backup is usually meant to undo whatever happened to the
+		// volume since, so by default we don't keep the files that came after it
+		return gui.confirmOverwriteVolume(volume, true, func(replace bool) error {
+			return gui.WithProgressStatus(gui.Tr.RestoringStatus, func(onProgress func(string)) error {
+				// we know how big the archive is, so we can say how far along we are
+				progress := func(bytes int64) {
+					onProgress(utils.FormatBinaryBytes(int(bytes)) + "/" + utils.FormatBinaryBytes(int(info.Size())))
+				}
+				if err := gui.DockerCommand.RestoreVolume(context.Background(), volume.Name, archivePath, replace, progress); err != nil {
+					return err
+				}
+
+				return gui.volumeArchiveReport(gui.Tr.RestoreVolume, gui.Tr.VolumeRestored, volume.Name, archivePath)
+			})
+		})
+	})
+}
+
+func (gui *Gui) promptCopyFromOtherVolume(volume *commands.Volume) error {
+	others := lo.Filter(gui.Panels.Volumes.List.GetAllItems(), func(other *commands.Volume, _ int) bool {
+		return other.Name != volume.Name
+	})
+	if len(others) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoOtherVolumes)
+	}
+
+	menuItems := lo.Map(others, func(src *commands.Volume, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			LabelColumns: presentation.GetVolumeDisplayStrings(gui.Tr, src, gui.volumeMounts(src)),
+			OnPress: func() error {
+				return gui.confirmOverwriteVolume(volume, false, func(replace bool) error {
+					return gui.copyVolume(src, volume, replace, false)
+				})
+			},
+		}
+	})
+
+	return gui.Menu(CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(gui.Tr.CopyFromVolumeTitle, map[string]string{"name": volume.Name}),
+		Items: menuItems,
+	})
+}
+
+func (gui *Gui) promptCloneVolume(volume *commands.Volume) error {
+	return gui.createPromptPanelWithInitialContent(gui.Tr.CloneVolumeNameTitle, volume.Name+"-clone", func(g *gocui.Gui, v *gocui.View) error {
+		name := gui.trimmedContent(v)
+		if name == "" {
+			return nil
+		}
+
+		if lo.SomeBy(gui.Panels.Volumes.List.GetAllItems(), func(other *commands.Volume) bool { return other.Name == name }) {
+			return gui.createErrorPanel(utils.ResolvePlaceholderString(gui.Tr.VolumeAlreadyExists, map[string]string{"name": name}))
+		}
+
+		return gui.WithWaitingStatus(gui.Tr.CreatingStatus, func() error {
+			if _, err := gui.DockerCommand.CreateVolume(volume.CloneOptions(name)); err != nil {
+				return err
+			}
+
+			return gui.copyVolume(volume, &commands.Volume{Name: name}, false, true)
+		})
+	})
+}
+
+// confirmOverwriteVolume runs the given function to write into the volume. If
+// the volume already has files in it, we first ask the user whether to replace
+// them or merge into them, offering the given choice first.
+func (gui *Gui) confirmOverwriteVolume(volume *commands.Volume, replaceByDefault bool, run func(replace bool) error) error {
+	return gui.WithWaitingStatus(gui.Tr.CheckingVolumeStatus, func() error {
+		empty, err := gui.DockerCommand.VolumeIsEmpty(context.Background(), volume.Name)
+		if err != nil {
+			return err
+		}
+
+		if empty {
+			return run(false)
+		}
+
+		items := []*types.MenuItem{
+			{
+				Label:   gui.Tr.ReplaceVolumeContents,
+				OnPress: func() error { return run(true) },
+			},
+			{
+				Label:   gui.Tr.MergeIntoVolume,
+				OnPress: func() error { return run(false) },
+			},
+		}
+		if !replaceByDefault {
+			items[0], items[1] = items[1], items[0]
+		}
+
+		gui.Update(func() error {
+			return gui.Menu(CreateMenuOptions{
+				Title: utils.ResolvePlaceholderString(gui.Tr.VolumeNotEmptyTitle, map[string]string{"name": volume.Name}),
+				Items: items,
+			})
+		})
+		return nil
+	})
+}
+
+// copyVolume copies the contents of src into dst, first removing what's in dst
+// if replace is true. If selectDst is true we select dst in the volumes panel
+// afterwards, e.g. when we've just created it.
+func (gui *Gui) copyVolume(src *commands.Volume, dst *commands.Volume, replace bool, selectDst bool) error {
+	return gui.WithProgressStatus(gui.Tr.CopyingStatus, func(onProgress func(string)) error {
+		if err := gui.DockerCommand.CopyVolume(context.Background(), src.Name, dst.Name, replace, bytesProgress(onProgress)); err != nil {
+			return err
+		}
+
+		if selectDst {
+			if err := gui.selectVolume(dst.Name); err != nil {
+				return err
+			}
+		}
+
+		return gui.createConfirmationPanel(gui.Tr.CopyFromVolume, utils.ResolvePlaceholderString(gui.Tr.VolumeCopied, map[string]string{
+			"src": src.Name,
+			"dst": dst.Name,
+		}), nil, nil)
+	})
+}
+
+// volumeArchiveReport tells the user that we've backed up or restored a volume,
+// along with how big the archive is
+func (gui *Gui) volumeArchiveReport(title string, message string, volumeName string, archivePath string) error {
+	size := ""
+	if info, err := os.Stat(archivePath); err == nil {
+		size = utils.FormatBinaryBytes(int(info.Size()))
+	}
+
+	return gui.createConfirmationPanel(title, utils.ResolvePlaceholderString(message, map[string]string{
+		"name": volumeName,
+		"path": archivePath,
+		"size": size,
+	}), nil, nil)
+}
@@ -0,0 +1,48 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+)
+
+// handleVolumesCreate prompts for the new volume's name, driver, driver options
+// and labels in turn and then creates it. If the user enters invalid driver
+// options or labels we ask for them again.
+func (gui *Gui) handleVolumesCreate(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.Tr.VolumeNameTitle, func(g *gocui.Gui, v *gocui.View) error {
+		options := commands.VolumeCreateOptions{Name: gui.trimmedContent(v)}
+
+		return gui.createPromptPanelWithInitialContent(gui.Tr.VolumeDriverTitle, "local", func(g *gocui.Gui, v *gocui.View) error {
+			options.Driver = gui.trimmedContent(v)
+
+			return gui.createValidatedPromptPanel(gui.Tr.VolumeDriverOptsTitle, "", validateKeyValues, func(content string) error {
+				options.DriverOpts, _ = commands.ParseKeyValues(content)
+
+				return gui.createValidatedPromptPanel(gui.Tr.LabelsTitle, "", validateKeyValues, func(content string) error {
+					options.Labels, _ = commands.ParseKeyValues(content)
+
+					return gui.createVolume(options)
+				})
+			})
+		})
+	})
+}
+
+func (gui *Gui) createVolume(options commands.VolumeCreateOptions) error {
+	return gui.WithWaitingStatus(gui.Tr.CreatingStatus, func() error {
+		name, err := gui.DockerCommand.CreateVolume(options)
+		if err != nil {
+			return err
+		}
+
+		return gui.selectVolume(name)
+	})
+}
+
+// selectVolume selects the volume with the given name in the volumes panel,
+// e.g. once we've created it
+func (gui *Gui) selectVolume(name string) error {
+	return selectListItem(gui, gui.Panels.Volumes, gui.refreshStateVolumes, func(volume *commands.Volume) bool {
+		return volume.Name == name
+	})
+}
@@ -0,0 +1,110 @@
+package gui
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/peauc/lazydocker-ng/pkg/commands"
+	"github.com/peauc/lazydocker-ng/pkg/tasks"
+	"github.com/peauc/lazydocker-ng/pkg/utils"
+)
+
+// renderVolumeFiles shows the files inside a volume. We read them through a
+// helper container that has the volume mounted, which we remove once the user
+// moves on from the tab. We only list directories as the user opens them, and
+// only read the file they're looking at, so that a big volume doesn't cost us
+// more than what the user sees.
+func (gui *Gui) renderVolumeFiles(volume *commands.Volume) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			gui.RenderStringMain(utils.ResolvePlaceholderString(gui.Tr.StartingVolumeHelper, map[string]string{
+				"image": gui.Config.UserConfig.VolumeHelper.Image,
+			}))
+
+			helper, err := gui.DockerCommand.NewVolumeHelper(ctx, volume.Name, true)
+			if err != nil {
+				if ctx.Err() == nil {
+					gui.RenderStringMain(err.Error())
+				}
+				return
+			}
+			// we hang on to the task until it's stopped so that the helper
+			// lives as long as the tab is showing
+			defer func() {
+				<-ctx.Done()
+				if err := helper.Close(); err != nil {
+					gui.Log.Error(err)
+				}
+			}()
+
+			gui.RenderStringMain(gui.Tr.LoadingFiles)
+
+			entries, err := helper.ListDir(ctx, commands.VolumeHelperMountPath)
+			if err != nil {
+				if ctx.Err() == nil {
+					gui.RenderStringMain(err.Error())
+				}
+				return
+			}
+
+			listDir := func(path string) ([]commands.ArchiveEntry, error) {
+				done := helper.Use()
+				defer done()
+				return helper.ListDir(ctx, path)
+			}
+			readFile := func(path string) ([]byte, bool, error) {
+				done := helper.Use()
+				defer done()
+				return helper.ReadFile(ctx, path, filePreviewLimit)
+			}
+			copyOut := func(path string) error {
+				return gui.promptCopyFromVolume(volume, path)
+			}
+
+			gui.setMainList(ctx, gui.fileTreeList(newFileTree(commands.VolumeHelperMountPath, entries), listDir, readFile, copyOut))
+		},
+	})
+}
+
+// promptCopyFromVolume asks where on the host to copy the given path (inside
+// a volume helper) to, and then copies it
+func (gui *Gui) promptCopyFromVolume(volume *commands.Volume, srcPath string) error {
+	return gui.createPromptPanelWithInitialContent(gui.Tr.CopyFromContainerDestinationTitle, path.Base(srcPath), func(g *gocui.Gui, v *gocui.View) error {
+		dstPath := gui.trimmedContent(v)
+		if dstPath == "" {
+			return nil
+		}
+
+		run := func() error {
+			return gui.WithProgressStatus(gui.Tr.CopyingStatus, func(onProgress func(string)) error {
+				// the files tab's helper goes away when the user moves on, which they
+				// may well do mid-copy, so we copy through a helper of our own
+				helper, err := gui.DockerCommand.NewVolumeHelper(context.Background(), volume.Name, true)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := helper.Close(); err != nil {
+						gui.Log.Error(err)
+					}
+				}()
+
+				return helper.CopyFromContainer(srcPath, dstPath, bytesProgress(onProgress))
+			})
+		}
+
+		target := commands.HostCopyDestination(srcPath, dstPath)
+		if _, err := os.Lstat(target); err != nil {
+			return run()
+		}
+
+		message := utils.ResolvePlaceholderString(gui.Tr.ConfirmCopyOverwrite, map[string]string{"path": target})
+		return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+			return run()
+		}, nil)
+	})
+}
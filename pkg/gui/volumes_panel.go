@@ -1,7 +1,9 @@
 package gui
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
@@ -25,6 +27,25 @@ func (gui *Gui) getVolumesPanel() *panels.SideListPanel[*commands.Volume] {
 						Title:  gui.Tr.ConfigTitle,
 						Render: gui.renderVolumeConfig,
 					},
+					{
+						Key:    "files",
+						Title:  gui.Tr.FilesTitle,
+						Render: gui.renderVolumeFiles,
+					},
+					{
+						Key:    "containers",
+						Title:  gui.Tr.ContainersTitle,
+						Render: gui.renderVolumeContainers,
+						// re-rendering when containers come and go. We leave the other tabs
+						// alone, as re-rendering the files tab would replace its helper
+						// container and collapse the file tree.
+						GetContextCacheKey: func(volume *commands.Volume) string {
+							mounts := lo.Map(gui.volumeMounts(volume), func(mount *commands.VolumeMount, _ int) string {
+								return mount.Container.ID + ":" + mount.Container.Container.State + ":" + mount.Mount.Destination
+							})
+							return strings.Join(mounts, ",")
+						},
+					},
 				}
 			},
 			GetItemContextCacheKey: func(volume *commands.Volume) string {
@@ -49,10 +70,15 @@ func (gui *Gui) getVolumesPanel() *panels.SideListPanel[*commands.Volume] {
 			}
 			return a.Name < b.Name
 		},
-		GetTableCells: presentation.GetVolumeDisplayStrings,
+		GetTableCells: func(volume *commands.Volume) []string {
+			return presentation.GetVolumeDisplayStrings(gui.Tr, volume, gui.volumeMounts(volume))
+		},
 		Hide: func() bool {
 			return gui.State.UIMode != MODE_RESSOURCES
 		},
+		GetItemKey: func(volume *commands.Volume) string {
+			return volume.Name
+		},
 	}
 }
 
@@ -77,7 +103,7 @@ func (gui *Gui) volumeConfigStr(volume *commands.Volume) string {
 			output += utils.FormatMapItem(padding, k, v)
 		}
 	} else {
-		output += "n/a"
+		output += "n/a\n"
 	}
 
 	if volume.Volume.UsageData != nil {
@@ -88,6 +114,49 @@ func (gui *Gui) volumeConfigStr(volume *commands.Volume) string {
 	return output
 }
 
+// volumeMounts returns the containers' mounts of the given volume
+func (gui *Gui) volumeMounts(volume *commands.Volume) []*commands.VolumeMount {
+	return volume.Mounts(gui.Panels.Containers.List.GetAllItems())
+}
+
+func (gui *Gui) renderVolumeContainers(volume *commands.Volume) tasks.TaskFunc {
+	return gui.NewTask(TaskOpts{
+		Autoscroll: false,
+		Wrap:       false,
+		Func: func(ctx context.Context) {
+			mounts := gui.volumeMounts(volume)
+			if len(mounts) == 0 {
+				gui.RenderStringMain(gui.Tr.VolumeNotInUse)
+				return
+			}
+
+			containers := commands.VolumeMountContainers(mounts)
+			header := utils.ResolvePlaceholderString(gui.Tr.VolumeUsedBy, map[string]string{
+				"count": fmt.Sprint(len(containers)),
+			})
+			extraCells := func(ctr *commands.Container) []string {
+				return []string{gui.volumeMountsStr(mounts, ctr)}
+			}
+			gui.setMainList(ctx, gui.containerUsageList(header, containers, extraCells))
+		},
+	})
+}
+
+// volumeMountsStr describes where the given container mounts a volume and
+// whether it can write to it, e.g. '/var/lib/data (rw)'
+func (gui *Gui) volumeMountsStr(mounts []*commands.VolumeMount, ctr *commands.Container) string {
+	return strings.Join(lo.FilterMap(mounts, func(mount *commands.VolumeMount, _ int) (string, bool) {
+		if mount.Container != ctr {
+			return "", false
+		}
+		mode := utils.ColoredString("ro", color.FgYellow)
+		if mount.Mount.RW {
+			mode = utils.ColoredString("rw", color.FgGreen)
+		}
+		return mount.Mount.Destination + " (" + mode + ")", true
+	}), ", ")
+}
+
 func (gui *Gui) reloadVolumes() error {
 	if err := gui.refreshStateVolumes(); err != nil {
 		return err
@@ -108,6 +177,10 @@ func (gui *Gui) refreshStateVolumes() error {
 }
 
 func (gui *Gui) handleVolumesRemoveMenu(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Volumes.MarkedItems(); len(marked) > 0 {
+		return gui.batchVolumeRemoveMenu(marked)
+	}
+
 	volume, err := gui.Panels.Volumes.GetSelectedItem()
 	if err != nil {
 		return nil
@@ -146,25 +219,37 @@ func (gui *Gui) handleVolumesRemoveMenu(g *gocui.Gui, v *gocui.View) error {
 		}
 	})
 
-	return gui.Menu(CreateMenuOptions{
-		Title: "",
-		Items: menuItems,
+	showMenu := func() error {
+		return gui.Menu(CreateMenuOptions{
+			Title: "",
+			Items: menuItems,
+		})
+	}
+
+	// docker will tell the user if the volume's in use, but not by what
+	mounts := gui.volumeMounts(volume)
+	if len(mounts) == 0 {
+		return showMenu()
+	}
+
+	users := lo.Map(commands.VolumeMountContainers(mounts), func(ctr *commands.Container, _ int) string {
+		return "  " + utils.ColoredString(ctr.Name, color.FgYellow) + " " + gui.volumeMountsStr(mounts, ctr)
+	})
+	message := utils.ResolvePlaceholderString(gui.Tr.ConfirmRemoveVolumeInUse, map[string]string{
+		"name":       volume.Name,
+		"containers": strings.Join(users, "\n"),
 	})
-}
 
-func (gui *Gui) handlePruneVolumes() error {
-	return gui.createConfirmationPanel(gui.Tr.Confirm, gui.Tr.ConfirmPruneVolumes, func(g *gocui.Gui, v *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.PruningStatus, func() error {
-			err := gui.DockerCommand.PruneVolumes()
-			if err != nil {
-				return gui.createErrorPanel(err.Error())
-			}
-			return nil
-		})
+	return gui.createConfirmationPanel(gui.Tr.Confirm, message, func(g *gocui.Gui, v *gocui.View) error {
+		return showMenu()
 	}, nil)
 }
 
 func (gui *Gui) handleVolumesCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	if marked := gui.Panels.Volumes.MarkedItems(); len(marked) > 0 {
+		return gui.batchVolumesCustomCommand(marked)
+	}
+
 	volume, err := gui.Panels.Volumes.GetSelectedItem()
 	if err != nil {
 		return nil
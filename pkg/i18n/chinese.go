@@ -115,12 +115,8 @@ func chineseSet() TranslationSet {
 		ConfirmUpProject:           "您确定要“up”的docker compose项目吗？",
 		MustForceToRemoveContainer: "您无法删除正在运行的容器，除非您强制执行。您想强制执行吗？",
 		NotEnoughSpace:             "空间不足，无法渲染面板",
-		ConfirmPruneImages:         "您确定要删除所有未使用的镜像吗？",
-		ConfirmPruneContainers:     "您确定要删除所有停止的容器吗？",
 		ConfirmStopContainers:      "您确定要停止所有容器吗？",
 		ConfirmRemoveContainers:    "您确定要删除所有容器吗？",
-		ConfirmPruneVolumes:        "您确定要删除所有未使用的卷吗？",
-		ConfirmPruneNetworks:       "您确定要删除所有未使用的网络吗？",
 		StopService:                "您确定要停止此服务的容器吗？",
 		StopContainer:              "您确定要停止此容器吗？",
 		PressEnterToReturn:         "按 enter 返回 lazydocker（您可以在配置文件中设置 `gui.returnImmediately: true` 来禁用此提示）",
@@ -85,13 +85,9 @@ func dutchSet() TranslationSet {
 		ConfirmQuit:                 "Weet je zeker dat je weg wil gaan?",
 		MustForceToRemoveContainer:  "Je kan geen draaiende container verwijderen tenzij je het forceert, Wil je het forceren?",
 		NotEnoughSpace:              "Niet genoeg ruimte om de panelen te renderen",
-		ConfirmPruneImages:          "Weet je zeker dat je alle niet gebruikte images wil vernietigen?",
-		ConfirmPruneContainers:      "Weet je zeker dat je alle niet gestopte containers wil vernietigen?",
-		ConfirmPruneVolumes:         "Weet je zeker dat je alle niet gebruikte volumes wil vernietigen?",
-		ConfirmPruneNetworks:        "Weet je zeker dat je alle niet gebruikte networks wil vernietigen?",
 		StopService:                 "Weet je zeker dat je deze service zijn containers wil stoppen?",
 		StopContainer:               "Weet je zeker dat je deze container wil stoppen?",
 		PressEnterToReturn:          "Druk op enter om terug te gaan naar lazydocker (Deze popup kan uit gezet worden door in de config dit neer te zetten `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "Als u wilt loskoppelen van de container, drukt u standaard op ctrl-p en vervolgens op ctrl-q",
+		DetachFromContainerShortCut: "Als u wilt loskoppelen van de container, drukt u op {{detachKeys}}",
 	}
 }
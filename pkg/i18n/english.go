@@ -44,6 +44,7 @@ type TranslationSet struct {
 	RestartingStatus            string
 	StartingStatus              string
 	StoppingStatus              string
+	StartingStoppingStatus      string
 	UppingProjectStatus         string
 	UppingServiceStatus         string
 	PausingStatus               string
@@ -51,6 +52,16 @@ type TranslationSet struct {
 	DowningStatus               string
 	RunningCustomCommandStatus  string
 	RunningBulkCommandStatus    string
+	CopyingStatus               string
+	PreparingCopyStatus         string
+	LoadingFileStatus           string
+	CommittingStatus            string
+	ExportingStatus             string
+	SavingStatus                string
+	LoadingImagesStatus         string
+	PullingStatus               string
+	BuildingStatus              string
+	PushingStatus               string
 	RemoveService               string
 	UpService                   string
 	Stop                        string
@@ -91,12 +102,8 @@ type TranslationSet struct {
 	PruneContainers             string
 	PruneVolumes                string
 	PruneNetworks               string
-	ConfirmPruneContainers      string
 	ConfirmStopContainers       string
 	ConfirmRemoveContainers     string
-	ConfirmPruneImages          string
-	ConfirmPruneVolumes         string
-	ConfirmPruneNetworks        string
 	PruningStatus               string
 	StopService                 string
 	PressEnterToReturn          string
@@ -112,6 +119,32 @@ type TranslationSet struct {
 	SortContainersByState       string
 	SwitchProject               string
 	ToggleProjectMode           string
+	CopyFiles                   string
+	CopyFromContainer           string
+	CopyToContainer             string
+	PressMainListItem           string
+	CommitContainer             string
+	ExportContainer             string
+	SaveLoadImages              string
+	SaveImage                   string
+	SaveListedImages            string
+	SaveMarkedImages            string
+	LoadImages                  string
+	PullImage                   string
+	PullImageByReference        string
+	RepullImage                 string
+	ShowPullProgress            string
+	CancelPull                  string
+	BuildImage                  string
+	ShowBuildOutput             string
+	CancelBuild                 string
+	ManageTags                  string
+	AddTag                      string
+	RemoveTag                   string
+	PushImage                   string
+	PushTag                     string
+	ShowPushProgress            string
+	CancelPush                  string
 
 	LogsTitle                 string
 	ConfigTitle               string
@@ -122,10 +155,256 @@ type TranslationSet struct {
 	AboutTitle                string
 	ContainerConfigTitle      string
 	ContainerEnvTitle         string
+	FilesTitle                string
+	DiffTitle                 string
+	TagsTitle                 string
+	LayersTitle               string
 	NothingToDisplay          string
 	NoContainerForService     string
 	CannotDisplayEnvVariables string
 
+	CopyFromContainerSourceTitle      string
+	CopyFromContainerDestinationTitle string
+	CopyToContainerSourceTitle        string
+	CopyToContainerDestinationTitle   string
+	CopyPreview                       string
+	CopyPreviewMore                   string
+	ConfirmCopyOverwrite              string
+
+	LoadingFiles         string
+	FilesSummary         string
+	BinaryFilePreview    string
+	TruncatedFilePreview string
+
+	NoFilesystemChanges      string
+	FilesystemChangesSummary string
+
+	CommitReferenceTitle string
+	CommitAuthorTitle    string
+	CommitMessageTitle   string
+	CommitChangesTitle   string
+
+	ExportContainerTitle string
+	SaveImagesTitle      string
+	LoadImagesTitle      string
+
+	PullImageTitle string
+	PullingImage   string
+	PullCancelled  string
+
+	BuildContextTitle    string
+	BuildDockerfileTitle string
+	BuildTagTitle        string
+	BuildArgsTitle       string
+	BuildTargetTitle     string
+	BuildPlatformTitle   string
+	BuildingImage        string
+	BuildSucceeded       string
+	BuildCancelled       string
+
+	AddTagTitle          string
+	ConfirmRemoveTag     string
+	RemoveLastTagWarning string
+	NoTagsToPush         string
+	PushingImage         string
+	PushSucceeded        string
+	PushCancelled        string
+	NoTags               string
+	TagsSummary          string
+	AnalysingLayers      string
+	LayersSummary        string
+	WastedSpaceTitle     string
+	ImageNotInUse        string
+	ImageUsedBy          string
+	ImageInUseWarning    string
+	ContainerNotShown    string
+	ToggleImagesView     string
+	DanglingImagesView   string
+	UnusedImagesView     string
+	PruneDanglingImages  string
+	PruneUnusedImages    string
+	PruneUntilTitle      string
+	PruneLabelsTitle     string
+	PreparingPruneStatus string
+	NothingToPrune       string
+	ImagePrunePreview    string
+	ImagePruneReport     string
+	DiskUsageTitle       string
+	ViewDiskUsage        string
+	CalculatingDiskUsage string
+	BuildCacheTitle      string
+	TypeColumn           string
+	TotalColumn          string
+	ActiveColumn         string
+	SizeColumn           string
+	ReclaimableColumn    string
+	NoItems              string
+
+	ViewBuildCache             string
+	LoadingBuildCache          string
+	BuildCacheSummary          string
+	IDColumn                   string
+	LastUsedColumn             string
+	SharedColumn               string
+	InUseColumn                string
+	DescriptionColumn          string
+	TimeAgo                    string
+	PruneBuildCache            string
+	PruneAllBuildCache         string
+	PruneDanglingBuildCache    string
+	PruneBuildCacheOlderThan   string
+	PruneBuildCacheKeepStorage string
+	BuildCacheUntilTitle       string
+	BuildCacheKeepStorageTitle string
+	BuildCachePrunePreview     string
+	BuildCachePruneReport      string
+
+	VulnerabilitiesTitle    string
+	ScanningImage           string
+	ScanImageNotConfigured  string
+	ScanImage               string
+	SortVulnerabilities     string
+	VulnerabilitiesSummary  string
+	NoVulnerabilities       string
+	VulnerabilitiesSortedBy string
+	SeverityColumn          string
+	PackageColumn           string
+	InstalledVersionColumn  string
+	FixedVersionColumn      string
+	TitleColumn             string
+
+	PullAndRecreate        string
+	ConfirmPullAndRecreate string
+	ImageUpdateAvailable   string
+	NoImageTagToPull       string
+	RecreatingStatus       string
+
+	CreateVolume          string
+	CreateNetwork         string
+	CreatingStatus        string
+	VolumeNameTitle       string
+	VolumeDriverTitle     string
+	VolumeDriverOptsTitle string
+	LabelsTitle           string
+	NetworkNameTitle      string
+	NetworkDriverTitle    string
+	SubnetsTitle          string
+	GatewaysTitle         string
+	IPRangesTitle         string
+	CreateNetworkTitle    string
+	NetworkInternal       string
+	NetworkAttachable     string
+	NetworkEnableIPv6     string
+
+	ConnectToNetwork                    string
+	DisconnectFromNetwork               string
+	ConnectContainer                    string
+	DisconnectContainer                 string
+	ConnectContainerToNetworkTitle      string
+	DisconnectContainerFromNetworkTitle string
+	ConnectContainerTitle               string
+	DisconnectContainerTitle            string
+	NoNetworksToConnect                 string
+	NoNetworksToDisconnect              string
+	NoContainersToConnect               string
+	NoContainersToDisconnect            string
+	AliasesTitle                        string
+	IPv4AddressTitle                    string
+	IPv6AddressTitle                    string
+	ConfirmDisconnectNetwork            string
+	ConnectingStatus                    string
+	DisconnectingStatus                 string
+	ContainerColumn                     string
+	NetworkColumn                       string
+	IPv4Column                          string
+	IPv6Column                          string
+	MACColumn                           string
+	AliasesColumn                       string
+
+	TopologyTitle          string
+	HostTitle              string
+	ReachabilityTitle      string
+	NoPublishedPorts       string
+	NoContainersOnNetwork  string
+	NoContainersOnNetworks string
+	ReachesNoContainers    string
+	NetworkInternalShort   string
+
+	StartingVolumeHelper string
+
+	BackupAndRestore             string
+	BackupAndRestoreTitle        string
+	BackupVolume                 string
+	RestoreVolume                string
+	CopyFromVolume               string
+	CloneVolume                  string
+	BackupVolumeDestinationTitle string
+	RestoreVolumeSourceTitle     string
+	CopyFromVolumeTitle          string
+	CloneVolumeNameTitle         string
+	NotAnArchive                 string
+	NoOtherVolumes               string
+	VolumeAlreadyExists          string
+	BackingUpStatus              string
+	RestoringStatus              string
+	CheckingVolumeStatus         string
+	VolumeBackedUp               string
+	VolumeRestored               string
+	VolumeCopied                 string
+
+	VolumeNotInUse           string
+	VolumeUsedBy             string
+	ConfirmRemoveVolumeInUse string
+
+	ContainersNoun             string
+	NetworksNoun               string
+	VolumesNoun                string
+	PruneObjectsUntilTitle     string
+	PruneObjectsLabelsTitle    string
+	PruneAnonymousVolumes      string
+	PruneUnusedVolumes         string
+	ContainerPrunePreview      string
+	NetworkPrunePreview        string
+	VolumePrunePreview         string
+	VolumePrunePreviewWithSize string
+	ContainerPruneReport       string
+	NetworkPruneReport         string
+	VolumePruneReport          string
+
+	CleanUp                  string
+	CleanupTitle             string
+	RunCleanup               string
+	CleanupStoppedContainers string
+	CleanupUnusedNetworks    string
+	ConfirmCleanup           string
+	CleanupRemoved           string
+	CleanupReport            string
+
+	MarkItem                string
+	MarkRange               string
+	BatchItemDone           string
+	BatchReport             string
+	ConfirmStopMarked       string
+	BatchRemoveTitle        string
+	BatchCustomCommandTitle string
+	NoBatchCustomCommands   string
+
+	ListingDirectoryStatus string
+
+	AndMore string
+
+	ImageNotScanned string
+	NoCVEsBadge     string
+
+	RunningContainersCount string
+	StoppedContainersCount string
+
+	UpdateAvailableBadge string
+
+	VolumeNotEmptyTitle   string
+	ReplaceVolumeContents string
+	MergeIntoVolume       string
+
 	No  string
 	Yes string
 
@@ -148,12 +427,23 @@ func englishSet() TranslationSet {
 		RestartingStatus:           "restarting",
 		StartingStatus:             "starting",
 		StoppingStatus:             "stopping",
+		StartingStoppingStatus:     "starting and stopping",
 		UppingServiceStatus:        "upping service",
 		UppingProjectStatus:        "upping project",
 		DowningStatus:              "downing",
 		PausingStatus:              "pausing",
 		RunningCustomCommandStatus: "running custom command",
 		RunningBulkCommandStatus:   "running bulk command",
+		CopyingStatus:              "copying",
+		PreparingCopyStatus:        "preparing copy",
+		LoadingFileStatus:          "loading file",
+		CommittingStatus:           "committing",
+		ExportingStatus:            "exporting",
+		SavingStatus:               "saving",
+		LoadingImagesStatus:        "loading images",
+		PullingStatus:              "pulling",
+		BuildingStatus:             "building",
+		PushingStatus:              "pushing",
 
 		NoViewMachingNewLineFocusedSwitchStatement: "No view matching newLineFocused switch statement",
 
@@ -223,6 +513,32 @@ func englishSet() TranslationSet {
 		SortContainersByState:       "sort containers by state",
 		SwitchProject:               "switch project",
 		ToggleProjectMode:           "toggle project mode",
+		CopyFiles:                   "copy files to/from container",
+		CopyFromContainer:           "copy from container to host",
+		CopyToContainer:             "copy from host to container",
+		PressMainListItem:           "expand/open selected item",
+		CommitContainer:             "commit to new image",
+		ExportContainer:             "export filesystem to tar archive",
+		SaveLoadImages:              "save/load image archives",
+		SaveImage:                   "save image (with all tags) to tar archive",
+		SaveListedImages:            "save all {{count}} listed images to tar archive",
+		SaveMarkedImages:            "save {{count}} marked images to tar archive",
+		LoadImages:                  "load images from tar archive",
+		PullImage:                   "pull image",
+		PullImageByReference:        "pull image by reference",
+		RepullImage:                 "re-pull {{ref}}",
+		ShowPullProgress:            "show progress of pulling {{ref}}",
+		CancelPull:                  "cancel pulling {{ref}}",
+		BuildImage:                  "build image",
+		ShowBuildOutput:             "show build output",
+		CancelBuild:                 "cancel build",
+		ManageTags:                  "add/remove tags",
+		AddTag:                      "add tag",
+		RemoveTag:                   "remove tag {{ref}}",
+		PushImage:                   "push image",
+		PushTag:                     "push {{ref}}",
+		ShowPushProgress:            "show progress of pushing {{ref}}",
+		CancelPush:                  "cancel pushing {{ref}}",
 
 		GlobalTitle:               "Global",
 		MainTitle:                 "Main",
@@ -246,10 +562,256 @@ func englishSet() TranslationSet {
 		AboutTitle:                "About",
 		ContainerConfigTitle:      "Container Config",
 		ContainerEnvTitle:         "Container Env",
+		FilesTitle:                "Files",
+		DiffTitle:                 "Diff",
+		TagsTitle:                 "Tags",
+		LayersTitle:               "Layers",
 		NothingToDisplay:          "Nothing to display",
 		NoContainerForService:     "No logs to show; service is not associated with a container",
 		CannotDisplayEnvVariables: "Something went wrong while displaying environment variables",
 
+		CopyFromContainerSourceTitle:      "Container path to copy from:",
+		CopyFromContainerDestinationTitle: "Host path to copy to:",
+		CopyToContainerSourceTitle:        "Host path to copy from:",
+		CopyToContainerDestinationTitle:   "Container path to copy to:",
+		CopyPreview:                       "{{count}} entries ({{size}}) will be copied from '{{path}}':",
+		CopyPreviewMore:                   "... and {{count}} more",
+		ConfirmCopyOverwrite:              "'{{path}}' already exists and will be overwritten. Continue?",
+
+		LoadingFiles:         "Loading files...",
+		FilesSummary:         "{{dirs}} directories, {{files}} files ({{size}}) listed so far",
+		BinaryFilePreview:    "(binary file)",
+		TruncatedFilePreview: "(truncated)",
+
+		NoFilesystemChanges:      "No changes to the container's filesystem",
+		FilesystemChangesSummary: "{{added}} added, {{changed}} changed, {{deleted}} deleted",
+
+		CommitReferenceTitle: "Image repository:tag (optional):",
+		CommitAuthorTitle:    "Author (optional):",
+		CommitMessageTitle:   "Commit message (optional):",
+		CommitChangesTitle:   "Changes separated by ';' e.g. CMD [\"sh\"]; ENV FOO=bar; EXPOSE 80 (optional):",
+
+		ExportContainerTitle: "Export to (.tar, or .tar.gz to compress):",
+		SaveImagesTitle:      "Save to (.tar, or .tar.gz to compress):",
+		LoadImagesTitle:      "Load images from (.tar or .tar.gz):",
+
+		PullImageTitle: "Image to pull e.g. alpine:latest:",
+		PullingImage:   "Pulling {{ref}}",
+		PullCancelled:  "Pull cancelled",
+
+		BuildContextTitle:    "Build context directory:",
+		BuildDockerfileTitle: "Dockerfile (relative to the context directory):",
+		BuildTagTitle:        "Image repository:tag (optional):",
+		BuildArgsTitle:       "Build args separated by ';' e.g. VERSION=1.2; DEBUG (optional):",
+		BuildTargetTitle:     "Target stage (optional):",
+		BuildPlatformTitle:   "Platform e.g. linux/amd64 (optional):",
+		BuildingImage:        "Building",
+		BuildSucceeded:       "Build succeeded",
+		BuildCancelled:       "Build cancelled",
+
+		AddTagTitle:          "New repository:tag:",
+		ConfirmRemoveTag:     "Are you sure you want to remove the tag '{{ref}}'?",
+		RemoveLastTagWarning: "This is the image's only tag, so the image itself will be removed.",
+		NoTagsToPush:         "This image has no tags. Add a tag with the registry and repository you want to push to first.",
+		PushingImage:         "Pushing {{ref}}",
+		PushSucceeded:        "Push complete",
+		PushCancelled:        "Push cancelled",
+		NoTags:               "This image has no tags",
+		TagsSummary:          "{{count}} tags",
+		AnalysingLayers:      "Analysing layers (this reads the whole image, so may take a while)...",
+		LayersSummary:        "Efficiency: {{efficiency}}  Wasted: {{wasted}}  Total: {{total}}\n(press enter on a layer to see its files)",
+		WastedSpaceTitle:     "Files overwritten or deleted by later layers",
+		ImageNotInUse:        "No containers use this image",
+		ImageUsedBy:          "Used by {{count}} container(s) (press enter to go to one)",
+		ImageInUseWarning:    "This image is used by {{count}} container(s): {{containers}}\n\nDocker will refuse to remove it unless you force it, in which case those containers will be left using an untagged image. Continue?",
+		ContainerNotShown:    "That container isn't shown in the current project, or stopped containers are hidden",
+		ToggleImagesView:     "show all/dangling/unused images",
+		DanglingImagesView:   "dangling",
+		UnusedImagesView:     "unused",
+		PruneDanglingImages:  "dangling images",
+		PruneUnusedImages:    "all images not used by a container",
+		PruneUntilTitle:      "Only prune images older than (e.g. 24h or 2024-01-31, empty for any age)",
+		PruneLabelsTitle:     "Only prune images with labels (e.g. 'env=dev !keep', empty for any)",
+		PreparingPruneStatus: "preparing prune",
+		NothingToPrune:       "Nothing to prune",
+		ImagePrunePreview:    "This will remove {{count}} image(s), reclaiming at least {{unique}} ({{total}} counting layers shared with other images):",
+		ImagePruneReport:     "Removed {{count}} image(s), reclaiming {{size}}",
+		DiskUsageTitle:       "Disk usage (press enter on a row to list its objects, largest first; ● means in use)",
+		ViewDiskUsage:        "view disk usage",
+		CalculatingDiskUsage: "Calculating disk usage...",
+		BuildCacheTitle:      "Build cache",
+		TypeColumn:           "TYPE",
+		TotalColumn:          "TOTAL",
+		ActiveColumn:         "ACTIVE",
+		SizeColumn:           "SIZE",
+		ReclaimableColumn:    "RECLAIMABLE",
+		NoItems:              "none",
+
+		ViewBuildCache:             "view build cache",
+		LoadingBuildCache:          "Loading build cache...",
+		BuildCacheSummary:          "{{count}} build cache records, {{reclaimable}} reclaimable (press p to prune)",
+		IDColumn:                   "ID",
+		LastUsedColumn:             "LAST USED",
+		SharedColumn:               "SHARED",
+		InUseColumn:                "IN USE",
+		DescriptionColumn:          "DESCRIPTION",
+		TimeAgo:                    "{{duration}} ago",
+		PruneBuildCache:            "prune build cache",
+		PruneAllBuildCache:         "all build cache not in use",
+		PruneDanglingBuildCache:    "dangling build cache",
+		PruneBuildCacheOlderThan:   "build cache not used for a while",
+		PruneBuildCacheKeepStorage: "least recently used build cache, down to a size",
+		BuildCacheUntilTitle:       "Prune build cache last used before (e.g. 24h or 2024-01-31)",
+		BuildCacheKeepStorageTitle: "Keep the build cache under (e.g. 10GB)",
+		BuildCachePrunePreview:     "This will remove {{count}} build cache record(s), freeing up about {{size}}:",
+		BuildCachePruneReport:      "Removed {{count}} build cache record(s), reclaiming {{size}}",
+
+		VulnerabilitiesTitle:    "Vulnerabilities",
+		ScanningImage:           "Scanning image for vulnerabilities. The first scan may take a while as the scanner downloads its database...",
+		ScanImageNotConfigured:  "No vulnerability scanner is configured. Set commandTemplates.scanImage in your config to a command which prints a trivy or grype JSON report",
+		ScanImage:               "scan image for vulnerabilities",
+		SortVulnerabilities:     "sort vulnerabilities",
+		VulnerabilitiesSummary:  "Scanned {{time}}: {{counts}}",
+		NoVulnerabilities:       "No vulnerabilities found",
+		VulnerabilitiesSortedBy: "Sorted by {{column}} (press s to change)",
+		SeverityColumn:          "SEVERITY",
+		PackageColumn:           "PACKAGE",
+		InstalledVersionColumn:  "INSTALLED",
+		FixedVersionColumn:      "FIXED IN",
+		TitleColumn:             "TITLE",
+
+		PullAndRecreate:        "pull image and recreate",
+		ConfirmPullAndRecreate: "Pull {{ref}} and recreate {{name}} from it?",
+		ImageUpdateAvailable:   "The registry has a newer version of {{ref}} ({{digest}}).",
+		NoImageTagToPull:       "This container was created from an image ID or digest rather than a tag, so there's no newer version to pull",
+		RecreatingStatus:       "recreating",
+
+		CreateVolume:          "create volume",
+		CreateNetwork:         "create network",
+		CreatingStatus:        "creating",
+		VolumeNameTitle:       "Volume name (leave empty to generate one):",
+		VolumeDriverTitle:     "Volume driver:",
+		VolumeDriverOptsTitle: "Driver options separated by ';' e.g. type=tmpfs; device=tmpfs; o=size=100m (optional):",
+		LabelsTitle:           "Labels separated by ';' e.g. env=prod; team=web (optional):",
+		NetworkNameTitle:      "Network name:",
+		NetworkDriverTitle:    "Network driver:",
+		SubnetsTitle:          "Subnets in CIDR notation separated by ',' e.g. 172.28.0.0/16, fd00:28::/64 (optional):",
+		GatewaysTitle:         "Gateways separated by ',' (at most one per subnet) e.g. 172.28.0.1 (optional):",
+		IPRangesTitle:         "IP ranges to allocate container addresses from, separated by ',' (at most one per subnet) e.g. 172.28.5.0/24 (optional):",
+		CreateNetworkTitle:    "Create network {{name}}",
+		NetworkInternal:       "internal (no access to external networks)",
+		NetworkAttachable:     "attachable (by standalone containers, for swarm networks)",
+		NetworkEnableIPv6:     "enable IPv6",
+
+		ConnectToNetwork:                    "connect to network",
+		DisconnectFromNetwork:               "disconnect from network",
+		ConnectContainer:                    "connect container",
+		DisconnectContainer:                 "disconnect container",
+		ConnectContainerToNetworkTitle:      "Connect {{name}} to network",
+		DisconnectContainerFromNetworkTitle: "Disconnect {{name}} from network",
+		ConnectContainerTitle:               "Connect container to {{name}}",
+		DisconnectContainerTitle:            "Disconnect container from {{name}}",
+		NoNetworksToConnect:                 "This container is already connected to every network",
+		NoNetworksToDisconnect:              "This container isn't connected to any networks",
+		NoContainersToConnect:               "There are no containers to connect to this network",
+		NoContainersToDisconnect:            "There are no containers connected to this network",
+		AliasesTitle:                        "Aliases on the network separated by ',' e.g. db, postgres (optional):",
+		IPv4AddressTitle:                    "Static IPv4 address e.g. 172.28.5.10 (leave empty to have one assigned):",
+		IPv6AddressTitle:                    "Static IPv6 address e.g. fd00:28::10 (leave empty to have one assigned):",
+		ConfirmDisconnectNetwork:            "Are you sure you want to disconnect {{container}} from {{network}}?",
+		ConnectingStatus:                    "connecting",
+		DisconnectingStatus:                 "disconnecting",
+		ContainerColumn:                     "Container",
+		NetworkColumn:                       "Network",
+		IPv4Column:                          "IPv4",
+		IPv6Column:                          "IPv6",
+		MACColumn:                           "MAC",
+		AliasesColumn:                       "Aliases",
+
+		TopologyTitle:          "Topology",
+		HostTitle:              "Host",
+		ReachabilityTitle:      "Reachability",
+		NoPublishedPorts:       "no published ports",
+		NoContainersOnNetwork:  "no containers",
+		NoContainersOnNetworks: "no containers on any networks",
+		ReachesNoContainers:    "no other containers",
+		NetworkInternalShort:   "internal",
+
+		StartingVolumeHelper: "Creating a helper container from {{image}} to look inside the volume...",
+
+		BackupAndRestore:             "backup & restore",
+		BackupAndRestoreTitle:        "Back up or restore {{name}}",
+		BackupVolume:                 "back up to archive",
+		RestoreVolume:                "restore from archive",
+		CopyFromVolume:               "copy from another volume",
+		CloneVolume:                  "clone to a new volume",
+		BackupVolumeDestinationTitle: "Path of the .tar.gz archive to back up to:",
+		RestoreVolumeSourceTitle:     "Path of the archive to restore from (.tar, .tar.gz, .tar.bz2 or .tar.xz):",
+		CopyFromVolumeTitle:          "Copy into {{name}} from",
+		CloneVolumeNameTitle:         "Name of the new volume:",
+		NotAnArchive:                 "'{{path}}' is a directory, not an archive",
+		NoOtherVolumes:               "There are no other volumes to copy from",
+		VolumeAlreadyExists:          "There's already a volume called '{{name}}'",
+		BackingUpStatus:              "backing up",
+		RestoringStatus:              "restoring",
+		CheckingVolumeStatus:         "checking volume",
+		VolumeBackedUp:               "Backed up {{name}} to {{path}} ({{size}})",
+		VolumeRestored:               "Restored {{name}} from {{path}} ({{size}})",
+		VolumeCopied:                 "Copied the contents of {{src}} into {{dst}}",
+
+		VolumeNotInUse:           "No containers mount this volume",
+		VolumeUsedBy:             "Mounted by {{count}} container(s) (press enter to go to one)",
+		ConfirmRemoveVolumeInUse: "{{name}} is mounted by these containers:\n\n{{containers}}\n\nRemove it anyway?",
+
+		ContainersNoun:             "containers",
+		NetworksNoun:               "networks",
+		VolumesNoun:                "volumes",
+		PruneObjectsUntilTitle:     "Only prune {{objects}} created before (e.g. 24h or 2024-01-31, empty for any age)",
+		PruneObjectsLabelsTitle:    "Only prune {{objects}} with labels (e.g. 'env=dev !keep', empty for any)",
+		PruneAnonymousVolumes:      "anonymous volumes not used by a container",
+		PruneUnusedVolumes:         "all volumes not used by a container",
+		ContainerPrunePreview:      "This will remove {{count}} stopped container(s):",
+		NetworkPrunePreview:        "This will remove {{count}} unused network(s):",
+		VolumePrunePreview:         "This will remove {{count}} unused volume(s):",
+		VolumePrunePreviewWithSize: "This will remove {{count}} unused volume(s), reclaiming at least {{size}}:",
+		ContainerPruneReport:       "Removed {{count}} container(s), reclaiming {{size}}",
+		NetworkPruneReport:         "Removed {{count}} network(s)",
+		VolumePruneReport:          "Removed {{count}} volume(s), reclaiming {{size}}",
+
+		CleanUp:                  "clean up unused containers, networks, images, volumes and build cache",
+		CleanupTitle:             "Clean up: {{count}} item(s), about {{size}}",
+		RunCleanup:               "clean up the selected kinds",
+		CleanupStoppedContainers: "stopped containers",
+		CleanupUnusedNetworks:    "networks not used by a container",
+		ConfirmCleanup:           "This will remove:\n\n{{summary}}\n\nContinue?",
+		CleanupRemoved:           "removed {{count}}",
+		CleanupReport:            "Reclaimed {{size}} in total:",
+
+		MarkItem:                "mark/unmark for batch actions",
+		MarkRange:               "mark a range (toggle visual mode)",
+		BatchItemDone:           "done",
+		BatchReport:             "{{succeeded}} of {{count}} succeeded:",
+		ConfirmStopMarked:       "Are you sure you want to stop these {{count}} marked item(s)?\n\n{{items}}",
+		BatchRemoveTitle:        "Remove {{count}} marked item(s)",
+		BatchCustomCommandTitle: "Run against {{count}} marked item(s):",
+		NoBatchCustomCommands:   "None of the custom commands can run against several items at once. Commands which attach to a terminal only run against the selected item.",
+
+		ListingDirectoryStatus: "listing directory",
+
+		AndMore: "... and {{count}} more",
+
+		ImageNotScanned: "This image hasn't been scanned for vulnerabilities yet. Press 'S' in the images panel to scan it.",
+		NoCVEsBadge:     "✓ no CVEs",
+
+		RunningContainersCount: "{{count}} running",
+		StoppedContainersCount: "{{count}} stopped",
+
+		UpdateAvailableBadge: "⬆ update",
+
+		VolumeNotEmptyTitle:   "{{name}} isn't empty",
+		ReplaceVolumeContents: "replace its contents (remove the files already in it first)",
+		MergeIntoVolume:       "merge (overwrite files with the same paths, keep the others)",
+
 		NoContainers: "No containers",
 		NoContainer:  "No container",
 		NoImages:     "No images",
@@ -261,16 +823,12 @@ func englishSet() TranslationSet {
 		ConfirmUpProject:            "Are you sure you want to 'up' your docker compose project?",
 		MustForceToRemoveContainer:  "You cannot remove a running container unless you force it. Do you want to force it?",
 		NotEnoughSpace:              "Not enough space to render panels",
-		ConfirmPruneImages:          "Are you sure you want to prune all unused images?",
-		ConfirmPruneContainers:      "Are you sure you want to prune all stopped containers?",
 		ConfirmStopContainers:       "Are you sure you want to stop all containers?",
 		ConfirmRemoveContainers:     "Are you sure you want to remove all containers?",
-		ConfirmPruneVolumes:         "Are you sure you want to prune all unused volumes?",
-		ConfirmPruneNetworks:        "Are you sure you want to prune all unused networks?",
 		StopService:                 "Are you sure you want to stop this service's containers?",
 		StopContainer:               "Are you sure you want to stop this container?",
 		PressEnterToReturn:          "Press enter to return to lazydocker (this prompt can be disabled in your config by setting `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "By default, to detach from the container press ctrl-p then ctrl-q",
+		DetachFromContainerShortCut: "To detach from the container press {{detachKeys}}",
 
 		No:  "no",
 		Yes: "yes",
@@ -102,16 +102,12 @@ func frenchSet() TranslationSet {
 		ConfirmQuit:                 "Êtes-vous certain de vouloir quitter ?",
 		MustForceToRemoveContainer:  "Vous ne pouvez pas supprimer un conteneur qui tourne sans le forcer. Voulez-vous le forcer ?",
 		NotEnoughSpace:              "Manque d'espace pour afficher les différent panneaux",
-		ConfirmPruneImages:          "Êtes-vous certain de vouloir détruire toutes les images non utilisées ?",
-		ConfirmPruneContainers:      "Êtes-vous certain de vouloir détruire tous les conteneurs arrêtés ?",
 		ConfirmStopContainers:       "Êtes-vous certain de vouloir arrêter tous les conteneurs ?",
 		ConfirmRemoveContainers:     "Êtes-vous certain de vouloir supprimer tous les conteneurs ?",
-		ConfirmPruneVolumes:         "Êtes-vous certain de vouloir détruire tous les volumes non utilisés ?",
-		ConfirmPruneNetworks:        "Êtes-vous certain de vouloir détruire tous les réseaux non utilisés ?",
 		StopService:                 "Êtes-vous certain de vouloir arrêter le conteneur de ce service ?",
 		StopContainer:               "Êtes-vous certain de vouloir arrêter ce conteneur ?",
 		PressEnterToReturn:          "Appuyez sur Entrée pour revenir à lazydocker (ce message peut être désactivé dans vos configurations en appliquant `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "Par défaut, pour se détacher du conteneur appuyez sur CTRL-P puis CTRL-Q",
+		DetachFromContainerShortCut: "Pour se détacher du conteneur appuyez sur {{detachKeys}}",
 
 		No:  "non",
 		Yes: "oui",
@@ -84,13 +84,9 @@ func germanSet() TranslationSet {
 		ConfirmQuit:                 "Bist du dir sicher, dass du verlassen möchtest?",
 		MustForceToRemoveContainer:  "Du kannst keinen Container entfernen, der noch ausgeführt wird außer du erzwingst es. Möchtest du es erzwingen?",
 		NotEnoughSpace:              "Nicht genug Platz um die Panel darzustellen",
-		ConfirmPruneImages:          "Bist du dir sicher, dass du alle unbenutzten Images entfernen möchtest?",
-		ConfirmPruneContainers:      "Bist du dir sicher, dass du alle angehaltenen Container entfernen möchtes?",
-		ConfirmPruneVolumes:         "Bist du dir sicher, dass du alle unbenutzen Volumes entfernen möchtest?",
-		ConfirmPruneNetworks:        "Bist du dir sicher, dass du alle unbenutzen Netzwerk entfernen möchtest?",
 		StopService:                 "Bist du dir sicher, dass du den Dienst dieses Containers anhalten möchtest?",
 		StopContainer:               "Bist du dir sicher, dass du den Container anhalten möchtest?",
 		PressEnterToReturn:          "Drücke Eingabe um zu lazydocker zurückzukehren. (Diese Nachfrage kann in Deiner Konfiguration deaktiviert werden, indem du folgenden Wert setzt: `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "Um sich vom Container zu trennen, drücken Sie standardmäßig ctrl-p und dann ctrl-q",
+		DetachFromContainerShortCut: "Um sich vom Container zu trennen, drücken Sie {{detachKeys}}",
 	}
 }
@@ -84,13 +84,9 @@ func polishSet() TranslationSet {
 		ConfirmQuit:                 "Na pewno chcesz wyjść?",
 		MustForceToRemoveContainer:  "Nie możesz usunąć uruchomionego kontenera dopóki nie zrobisz tego siłą. Chcesz wykonać to z siłą?",
 		NotEnoughSpace:              "Niedostateczna ilość miejsca do wyświetlenia paneli",
-		ConfirmPruneImages:          "Na pewno wyczyścić wszystkie nieużywane obrazy?",
-		ConfirmPruneContainers:      "Na pewno wyczyścić wszystkie nieuruchomione kontenery?",
-		ConfirmPruneVolumes:         "Na pewno wyczyścić wszystkie nieużywane wolumeny?",
-		ConfirmPruneNetworks:        "Na pewno wyczyścić wszystkie nieużywane sieci?",
 		StopService:                 "Na pewno zatrzymać kontenery tego serwisu?",
 		StopContainer:               "Na pewno zatrzymać ten kontener?",
 		PressEnterToReturn:          "Wciśnij enter aby powrócić do lazydockera (ten komunikat może być wyłączony w konfiguracji poprzez ustawienie `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "Domyślnie, aby odłączyć się od kontenera, naciśnij ctrl-p, a następnie ctrl-q",
+		DetachFromContainerShortCut: "Aby odłączyć się od kontenera, naciśnij {{detachKeys}}",
 	}
 }
@@ -115,16 +115,12 @@ func portugueseSet() TranslationSet {
 		ConfirmUpProject:            "Tem certeza que deseja 'iniciar' seu projeto docker compose?",
 		MustForceToRemoveContainer:  "Você não pode remover um contêiner em execução a menos que o force. Deseja forçar?",
 		NotEnoughSpace:              "Sem espaço suficiente para renderizar os painéis",
-		ConfirmPruneImages:          "Tem certeza que deseja eliminar todas as imagens não utilizadas?",
-		ConfirmPruneContainers:      "Tem certeza que deseja destruir todos os contêineres parados?",
 		ConfirmStopContainers:       "Tem certeza que deseja parar todos os contêineres?",
 		ConfirmRemoveContainers:     "Tem certeza que deseja remover todos os contêineres?",
-		ConfirmPruneVolumes:         "Tem certeza que deseja destruir todos os volumes não utilizados?",
-		ConfirmPruneNetworks:        "Tem certeza que deseja destruir todas as redes não utilizadas?",
 		StopService:                 "Tem certeza que deseja parar os contêineres deste serviço?",
 		StopContainer:               "Tem certeza que deseja parar este contêiner?",
 		PressEnterToReturn:          "Pressione enter para retornar ao lazydocker (este prompt pode ser desativado em sua configuração definindo `gui.returnImmediately: true`)",
-		DetachFromContainerShortCut: "Por padrão, para desanexar do contêiner, pressione ctrl-p e depois ctrl-q",
+		DetachFromContainerShortCut: "Para desanexar do contêiner, pressione {{detachKeys}}",
 
 		No:  "não",
 		Yes: "sim",
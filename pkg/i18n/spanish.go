@@ -110,12 +110,8 @@ func spanishSet() TranslationSet {
 		ConfirmUpProject:           "¿Realmente quieres levantar tu proyecto docker compose?",
 		MustForceToRemoveContainer: "No puedes borrar un contenedor en ejecución a menos de que lo fuerces, ¿quieres hacerlo?",
 		NotEnoughSpace:             "No hay suficiente espacio para renderizar los paneles",
-		ConfirmPruneImages:         "¿Realmente quieres limpiar todas tus imágenes?",
-		ConfirmPruneContainers:     "¿Realmente quieres limpiar todos los contenedores finalizados?",
 		ConfirmStopContainers:      "¿Realmente quieres detener todos los contenedores?",
 		ConfirmRemoveContainers:    "¿Realmente quieres borrar todos los contenedores?",
-		ConfirmPruneVolumes:        "¿Realmente quieres limpiar todos los vólumenes sin usar?",
-		ConfirmPruneNetworks:       "¿Realmente quieres limpiar todas las redes sin usar?",
 		StopService:                "¿Realmente quieres detener los contenedores de este servicio?",
 		StopContainer:              "¿Realmente quieres detener este contenedor?",
 		PressEnterToReturn:         "Presionar [enter] para volver a lazydocker (este mensaje puede ser desactivado en tu configuración poniendo `gui.returnImmediately: true`)",
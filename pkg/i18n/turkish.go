@@ -84,13 +84,9 @@ func turkishSet() TranslationSet {
 		ConfirmQuit:                 "Çıkmak istediğine emin misin?",
 		MustForceToRemoveContainer:  "Zorlamadan çalışan bir konteyneri kaldıramazsınız. Zorlamak ister misin?",
 		NotEnoughSpace:              "Panelleri oluşturmak için yeterli alan yok",
-		ConfirmPruneImages:          "Kullanılmayan tüm görüntüleri temizlemek istediğinize emin misiniz?",
-		ConfirmPruneContainers:      "Durdurulan tüm konteynerları temizlemek istediğinizden emin misiniz?",
-		ConfirmPruneVolumes:         "Kullanılmayan tüm alanları temizlemek istediğinizden emin misiniz?",
-		ConfirmPruneNetworks:        "Kullanılmayan tüm ağları temizlemek istediğinizden emin misiniz?",
 		StopService:                 "Bu servisin konteynerlerini durdurmak istediğinize emin misiniz?",
 		StopContainer:               "Bu konteyneri durdurmak istediğinize emin misiniz?",
 		PressEnterToReturn:          "lazydocker' a geri dönmek için enter tuşuna basın ( Bu uyarı, `gui.return Immediately: true` ayarıyla devre dışı bırakılabilir)",
-		DetachFromContainerShortCut: "Varsayılan olarak, kaptan ayırmak için ctrl-p ve ardından ctrl-q tuşlarına basın",
+		DetachFromContainerShortCut: "Kaptan ayırmak için {{detachKeys}} tuşlarına basın",
 	}
 }
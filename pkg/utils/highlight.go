@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	sourceTokenRegexp   = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|\b\d+(?:\.\d+)?\b`)
+	sourceKeyRegexp     = regexp.MustCompile(`^(\s*)([A-Za-z_][\w.\-]*)(\s*[=:])`)
+	sourceSectionRegexp = regexp.MustCompile(`^\s*\[[^\]]+\]\s*$`)
+)
+
+// ColoredSourceString colours the contents of a file based on its name, for
+// previewing it. YAML and JSON are fully highlighted; for anything else we
+// colour comments, quoted strings and numbers, along with keys and sections in
+// config files.
+func ColoredSourceString(filename string, str string) string {
+	ext := strings.ToLower(path.Ext(filename))
+	switch ext {
+	case ".yaml", ".yml", ".json":
+		return ColoredYamlString(str)
+	}
+
+	commentPrefixes := sourceCommentPrefixes(ext)
+	isConfig := isConfigFile(filename, ext)
+
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		lines[i] = coloredSourceLine(line, commentPrefixes, isConfig)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func coloredSourceLine(line string, commentPrefixes []string, isConfig bool) string {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range commentPrefixes {
+		if strings.HasPrefix(trimmed, prefix) {
+			return ColoredString(line, color.FgBlue)
+		}
+	}
+
+	prefix := ""
+	if isConfig {
+		if sourceSectionRegexp.MatchString(line) {
+			return ColoredString(line, color.FgMagenta)
+		}
+
+		if match := sourceKeyRegexp.FindStringSubmatch(line); match != nil {
+			prefix = match[1] + ColoredString(match[2], color.FgCyan) + match[3]
+			line = line[len(match[0]):]
+		}
+	}
+
+	return prefix + sourceTokenRegexp.ReplaceAllStringFunc(line, func(token string) string {
+		if token[0] == '"' || token[0] == '\'' {
+			return ColoredString(token, color.FgGreen)
+		}
+		return ColoredString(token, color.FgYellow)
+	})
+}
+
+func sourceCommentPrefixes(ext string) []string {
+	switch ext {
+	case ".go", ".js", ".jsx", ".ts", ".tsx", ".c", ".h", ".cc", ".cpp", ".hpp", ".java", ".kt", ".rs", ".swift", ".scala", ".cs":
+		return []string{"//"}
+	case ".sql", ".lua":
+		return []string{"--"}
+	case ".ini":
+		return []string{";", "#"}
+	default:
+		// shell scripts, python, Dockerfiles and most config files
+		return []string{"#"}
+	}
+}
+
+func isConfigFile(filename string, ext string) bool {
+	switch ext {
+	case ".env", ".ini", ".conf", ".cfg", ".cnf", ".properties", ".toml":
+		return true
+	}
+
+	return strings.HasPrefix(path.Base(filename), ".env")
+}
@@ -0,0 +1,60 @@
+package utils
+
+import "container/list"
+
+// LRU is a cache holding up to a fixed number of values, evicting the least
+// recently used one to make room for a new one. It isn't safe for concurrent
+// use.
+type LRU[K comparable, V any] struct {
+	capacity int
+	order    *list.List
+	items    map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns an empty cache holding up to capacity values
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    map[K]*list.Element{},
+	}
+}
+
+// Get returns the value for the given key, if we have it
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// Add sets the value for the given key, evicting the least recently used value
+// if the cache is full
+func (c *LRU[K, V]) Add(key K, value V) {
+	if element, ok := c.items[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// Len returns the number of values in the cache
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
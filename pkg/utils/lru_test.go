@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLRU is a function.
+func TestLRU(t *testing.T) {
+	cache := NewLRU[string, int](2)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+
+	// using a makes b the least recently used
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, value)
+
+	cache.Add("c", 3)
+	assert.EqualValues(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+
+	value, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.EqualValues(t, 3, value)
+
+	cache.Add("a", 4)
+	value, _ = cache.Get("a")
+	assert.EqualValues(t, 4, value)
+	assert.EqualValues(t, 2, cache.Len())
+}
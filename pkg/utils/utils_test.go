@@ -3,6 +3,7 @@ package utils
 import (
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -319,3 +320,42 @@ quux:
 		}
 	}
 }
+
+// TestColoredSourceString is a function.
+func TestColoredSourceString(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	type scenario struct {
+		filename string
+		input    string
+		colored  []string
+	}
+
+	scenarios := []scenario{
+		{
+			"entrypoint.sh",
+			"# start the server\nexec server --port 8080 \"$@\"",
+			[]string{"\x1b[34m# start the server", "\x1b[33m8080", "\x1b[32m\"$@\""},
+		},
+		{
+			"main.go",
+			"// hello\nfmt.Println(\"hi\")",
+			[]string{"\x1b[34m// hello", "\x1b[32m\"hi\""},
+		},
+		{
+			"settings.ini",
+			"[server]\nport = 80",
+			[]string{"\x1b[35m[server]", "\x1b[36mport"},
+		},
+	}
+
+	for _, s := range scenarios {
+		output := ColoredSourceString(s.filename, s.input)
+		assert.EqualValues(t, s.input, Decolorise(output))
+		for _, colored := range s.colored {
+			assert.Contains(t, output, colored)
+		}
+	}
+}